@@ -1,14 +1,163 @@
 package main
 
-import revolt "github.com/WelcomerTeam/Revolt/internal"
+import (
+	"bytes"
+	"image/color"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	jsoniter "github.com/json-iterator/go"
+
+	revolt "github.com/WelcomerTeam/Revolt/revolt"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
 func main() {
 
 	bot := revolt.NewRevoltBot("tokenHere")
 
+	bot.AddHandler(onMessageCreate)
+	bot.AddHandler(onServerMemberJoin)
+
+	closed := make(chan struct{})
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+
+		err := bot.Close()
+		if err != nil {
+			println(err.Error())
+		}
+
+		close(closed)
+	}()
+
 	err := bot.Start()
 	if err != nil {
 		print(err.Error())
+
+		return
+	}
+
+	// Start only returns without an error once Close has been called.
+	<-closed
+
+}
+
+func onMessageCreate(rb *revolt.RevoltBot, o revolt.MessageCreate) {
+	if o.Message.Content == "/pog" {
+		_, err := revolt.NewMessageBuilder().Write("pog").Reply(rb, o.Message, false)
+		if err != nil {
+			println(err.Error())
+		}
+	}
+
+	if o.Message.Content == "/rock" {
+		f, _ := ioutil.ReadFile("C:/users/blane/desktop/realrock.png")
+		autumnID, err := rb.UploadFile("rock.png", f)
+		if err != nil {
+			println(err.Error())
+		}
+
+		println(autumnID)
+
+		_, err = revolt.NewMessageBuilder().Write("heres a rock").Attach(autumnID).Send(rb, o.Message.ChannelID)
+		if err != nil {
+			println(err.Error())
+		}
+	}
+
+	// println(o.Message.Author + " said '" + o.Message.Content + "' in channel " + o.Message.ChannelID)
+}
+
+func onServerMemberJoin(rb *revolt.RevoltBot, o revolt.ServerMemberJoin) {
+	g, ok := rb.Guild(o.GuildID)
+	if !ok || g.SystemMessages == nil || g.SystemMessages.UserJoined == "" {
+		return
+	}
+
+	channelID := g.SystemMessages.UserJoined
+
+	perms, err := rb.Permissions(channelID, rb.SelfID())
+	if err != nil {
+		println(err.Error())
+
+		return
+	}
+
+	if !perms.Has(revolt.ChannelPermissionSendMessage | revolt.ChannelPermissionUploadFiles) {
+		return
+	}
+
+	var b bytes.Buffer
+
+	user, err := rb.FetchUser(o.UserID)
+	if err != nil {
+		println(err.Error())
+
+		return
+	}
+
+	avatarURL := ""
+	if user.Avatar != nil {
+		avatarURL = rb.AutumnURL() + "/avatars/" + user.Avatar.ID + "?format=png&max_side=256"
+	}
+
+	json.NewEncoder(&b).Encode(revolt.ImageCreateArguments{
+		FilesizeLimit: 10000000,
+		Options: revolt.ImageOpts{
+			Text:                "Welcome " + user.Username,
+			ImageURL:            avatarURL,
+			Background:          "revolt",
+			Font:                "Raleway-Bold",
+			BorderColour:        color.RGBA{253, 68, 83, 0},
+			BorderWidth:         16,
+			TextAlignmentX:      1,
+			TextAlignmentY:      1,
+			ProfileBorderColour: color.RGBA{17, 24, 34, 0},
+			TextStroke:          8,
+			TextStrokeColour:    color.RGBA{255, 255, 255, 0},
+			TextColour:          color.RGBA{253, 68, 83, 0},
+		},
+	})
+
+	resp, err := http.Post("http://localhost:4200/images", "application/json", &b)
+	if err != nil {
+		println(err.Error())
+
+		return
+	}
+	defer resp.Body.Close()
+
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	autumnID, err := rb.UploadFile("welcome.png", body)
+	if err != nil {
+		println(err.Error())
+
+		return
+	}
+
+	msg, err := rb.SendMessage(channelID, &revolt.MessageRequest{
+		Embeds: []*revolt.SendableEmbed{
+			{
+				Title:  "Welcome to " + g.Name,
+				Colour: "#FD4453",
+				Media:  autumnID,
+			},
+		},
+	})
+	if err != nil {
+		println(err.Error())
+
+		return
 	}
 
+	println(msg, msg.ID)
 }
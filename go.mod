@@ -3,9 +3,11 @@ module github.com/WelcomerTeam/Revolt
 go 1.16
 
 require (
-	github.com/json-iterator/go v1.1.11 // indirect
-	github.com/savsgio/gotils v0.0.0-20210617111740-97865ed5a873 // indirect
-	github.com/vmihailenco/msgpack v4.0.4+incompatible // indirect
+	github.com/json-iterator/go v1.1.12
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/savsgio/gotils v0.0.0-20210617111740-97865ed5a873
+	github.com/vmihailenco/msgpack v4.0.4+incompatible
+	go.etcd.io/bbolt v1.3.6
 	golang.org/x/image v0.0.0-20210628002857-a66eb6448b8d // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	nhooyr.io/websocket v1.8.7
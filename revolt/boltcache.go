@@ -0,0 +1,256 @@
+package revolt
+
+import (
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	boltUsersBucket    = []byte("users")
+	boltGuildsBucket   = []byte("guilds")
+	boltChannelsBucket = []byte("channels")
+	boltMembersBucket  = []byte("members")
+)
+
+// BoltCache is a Cache stored on disk with bbolt, so it can outlive the
+// process and be shared by bots started one after another. Values are
+// stored as JSON.
+type BoltCache struct {
+	db *bolt.DB
+
+	// Transaction used by the Cache passed to Batch.
+	tx *bolt.Tx
+}
+
+// NewBoltCache opens or creates the database at path.
+func NewBoltCache(path string) (bc *BoltCache, err error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltUsersBucket, boltGuildsBucket, boltChannelsBucket, boltMembersBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return &BoltCache{db: db}, nil
+}
+
+// Close closes the database.
+func (bc *BoltCache) Close() error {
+	return bc.db.Close()
+}
+
+// Batch calls f with a Cache that writes in a single transaction, which is
+// committed once f returns.
+func (bc *BoltCache) Batch(f func(c Cache)) {
+	if bc.tx != nil {
+		f(bc)
+
+		return
+	}
+
+	err := bc.db.Update(func(tx *bolt.Tx) error {
+		f(&BoltCache{db: bc.db, tx: tx})
+
+		return nil
+	})
+	if err != nil {
+		println("Failed to write to bolt cache: " + err.Error())
+	}
+}
+
+// view runs fn in the transaction of a batch, or in a new read-only one.
+func (bc *BoltCache) view(fn func(tx *bolt.Tx) error) error {
+	if bc.tx != nil {
+		return fn(bc.tx)
+	}
+
+	return bc.db.View(fn)
+}
+
+// update runs fn in the transaction of a batch, or in a new one.
+func (bc *BoltCache) update(fn func(tx *bolt.Tx) error) error {
+	if bc.tx != nil {
+		return fn(bc.tx)
+	}
+
+	return bc.db.Update(fn)
+}
+
+func (bc *BoltCache) get(bucket []byte, key string, v interface{}) (ok bool) {
+	err := bc.view(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucket).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+
+		ok = true
+
+		return json.Unmarshal(data, v)
+	})
+	if err != nil {
+		println("Failed to read from bolt cache: " + err.Error())
+
+		return false
+	}
+
+	return ok
+}
+
+func (bc *BoltCache) set(bucket []byte, key string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = bc.update(func(tx *bolt.Tx) error {
+			return tx.Bucket(bucket).Put([]byte(key), data)
+		})
+	}
+
+	if err != nil {
+		println("Failed to write to bolt cache: " + err.Error())
+	}
+}
+
+func (bc *BoltCache) delete(bucket []byte, key string) {
+	err := bc.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Delete([]byte(key))
+	})
+	if err != nil {
+		println("Failed to delete from bolt cache: " + err.Error())
+	}
+}
+
+// rangeBucket calls f with every value in a bucket. The values are read
+// before f is called so f may write to the cache.
+func (bc *BoltCache) rangeBucket(bucket []byte, f func(data []byte) bool) {
+	var values [][]byte
+
+	err := bc.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).ForEach(func(_, v []byte) error {
+			values = append(values, append([]byte(nil), v...))
+
+			return nil
+		})
+	})
+	if err != nil {
+		println("Failed to read from bolt cache: " + err.Error())
+
+		return
+	}
+
+	for _, v := range values {
+		if !f(v) {
+			return
+		}
+	}
+}
+
+func (bc *BoltCache) GetUser(userID string) (user *User, ok bool) {
+	ok = bc.get(boltUsersBucket, userID, &user)
+
+	return user, ok
+}
+
+func (bc *BoltCache) SetUser(user *User) {
+	bc.set(boltUsersBucket, user.ID, user)
+}
+
+func (bc *BoltCache) DeleteUser(userID string) {
+	bc.delete(boltUsersBucket, userID)
+}
+
+func (bc *BoltCache) RangeUsers(f func(user *User) bool) {
+	bc.rangeBucket(boltUsersBucket, func(data []byte) bool {
+		var user *User
+		if json.Unmarshal(data, &user) != nil {
+			return true
+		}
+
+		return f(user)
+	})
+}
+
+func (bc *BoltCache) GetGuild(guildID string) (guild *Guild, ok bool) {
+	ok = bc.get(boltGuildsBucket, guildID, &guild)
+
+	return guild, ok
+}
+
+func (bc *BoltCache) SetGuild(guild *Guild) {
+	bc.set(boltGuildsBucket, guild.ID, guild)
+}
+
+func (bc *BoltCache) DeleteGuild(guildID string) {
+	bc.delete(boltGuildsBucket, guildID)
+}
+
+func (bc *BoltCache) RangeGuilds(f func(guild *Guild) bool) {
+	bc.rangeBucket(boltGuildsBucket, func(data []byte) bool {
+		var guild *Guild
+		if json.Unmarshal(data, &guild) != nil {
+			return true
+		}
+
+		return f(guild)
+	})
+}
+
+func (bc *BoltCache) GetChannel(channelID string) (channel *Channel, ok bool) {
+	ok = bc.get(boltChannelsBucket, channelID, &channel)
+
+	return channel, ok
+}
+
+func (bc *BoltCache) SetChannel(channel *Channel) {
+	bc.set(boltChannelsBucket, channel.ID, channel)
+}
+
+func (bc *BoltCache) DeleteChannel(channelID string) {
+	bc.delete(boltChannelsBucket, channelID)
+}
+
+func (bc *BoltCache) RangeChannels(f func(channel *Channel) bool) {
+	bc.rangeBucket(boltChannelsBucket, func(data []byte) bool {
+		var channel *Channel
+		if json.Unmarshal(data, &channel) != nil {
+			return true
+		}
+
+		return f(channel)
+	})
+}
+
+func (bc *BoltCache) GetMember(guildID string, userID string) (member *GuildMember, ok bool) {
+	ok = bc.get(boltMembersBucket, memberKey(guildID, userID), &member)
+
+	return member, ok
+}
+
+func (bc *BoltCache) SetMember(member *GuildMember) {
+	bc.set(boltMembersBucket, memberKey(member.ID.Server, member.ID.User), member)
+}
+
+func (bc *BoltCache) DeleteMember(guildID string, userID string) {
+	bc.delete(boltMembersBucket, memberKey(guildID, userID))
+}
+
+func (bc *BoltCache) RangeMembers(f func(member *GuildMember) bool) {
+	bc.rangeBucket(boltMembersBucket, func(data []byte) bool {
+		var member *GuildMember
+		if json.Unmarshal(data, &member) != nil {
+			return true
+		}
+
+		return f(member)
+	})
+}
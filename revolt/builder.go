@@ -0,0 +1,228 @@
+package revolt
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Maximum number of characters in the content of a message.
+const MaxMessageContentLength = 2000
+
+// MessageBuilder builds the requests to send a message, splitting its
+// content over several messages when it is longer than
+// MaxMessageContentLength.
+type MessageBuilder struct {
+	content strings.Builder
+
+	attachments []string
+	embeds      []*SendableEmbed
+	masquerade  *Masquerade
+	replies     []*Reply
+}
+
+func NewMessageBuilder() *MessageBuilder {
+	return &MessageBuilder{}
+}
+
+// Write adds text to the content as is. Use Text for user input.
+func (mb *MessageBuilder) Write(text string) *MessageBuilder {
+	mb.content.WriteString(text)
+
+	return mb
+}
+
+// Text adds text to the content with markdown escaped.
+func (mb *MessageBuilder) Text(text string) *MessageBuilder {
+	return mb.Write(EscapeMarkdown(text))
+}
+
+// Line adds text followed by a new line.
+func (mb *MessageBuilder) Line(text string) *MessageBuilder {
+	return mb.Write(text + "\n")
+}
+
+// The formatting helpers below do not escape text, so it may contain
+// markdown itself. Formatting spanning a split is not carried over to the
+// next message.
+
+func (mb *MessageBuilder) Bold(text string) *MessageBuilder {
+	return mb.Write("**" + text + "**")
+}
+
+func (mb *MessageBuilder) Italic(text string) *MessageBuilder {
+	return mb.Write("*" + text + "*")
+}
+
+func (mb *MessageBuilder) Strikethrough(text string) *MessageBuilder {
+	return mb.Write("~~" + text + "~~")
+}
+
+func (mb *MessageBuilder) Spoiler(text string) *MessageBuilder {
+	return mb.Write("!!" + text + "!!")
+}
+
+func (mb *MessageBuilder) Code(text string) *MessageBuilder {
+	return mb.Write("`" + text + "`")
+}
+
+// CodeBlock adds a code block on its own lines. language may be empty.
+func (mb *MessageBuilder) CodeBlock(language string, code string) *MessageBuilder {
+	return mb.Write("```" + language + "\n" + strings.TrimSuffix(code, "\n") + "\n```\n")
+}
+
+// Quote adds text as a block quote.
+func (mb *MessageBuilder) Quote(text string) *MessageBuilder {
+	return mb.Write("> " + strings.Replace(strings.TrimSuffix(text, "\n"), "\n", "\n> ", -1) + "\n")
+}
+
+// Mention adds a mention of a user.
+func (mb *MessageBuilder) Mention(userID string) *MessageBuilder {
+	return mb.Write(MentionUser(userID))
+}
+
+// Channel adds a link to a channel.
+func (mb *MessageBuilder) Channel(channelID string) *MessageBuilder {
+	return mb.Write(MentionChannel(channelID))
+}
+
+// Attach adds an uploaded file to the last message.
+func (mb *MessageBuilder) Attach(autumnID string) *MessageBuilder {
+	mb.attachments = append(mb.attachments, autumnID)
+
+	return mb
+}
+
+// Embed adds an embed to the last message.
+func (mb *MessageBuilder) Embed(embed *SendableEmbed) *MessageBuilder {
+	mb.embeds = append(mb.embeds, embed)
+
+	return mb
+}
+
+// Masquerade sets the name and avatar shown for every message.
+func (mb *MessageBuilder) Masquerade(masquerade *Masquerade) *MessageBuilder {
+	mb.masquerade = masquerade
+
+	return mb
+}
+
+// ReplyTo makes the first message a reply to message.
+func (mb *MessageBuilder) ReplyTo(message *Message, mention bool) *MessageBuilder {
+	mb.replies = append(mb.replies, message.Reply(mention))
+
+	return mb
+}
+
+// Build returns the requests to send the message. The first one has the
+// replies and the last one the attachments and embeds.
+func (mb *MessageBuilder) Build() (messageRequests []*MessageRequest) {
+	for _, content := range splitContent(mb.content.String(), MaxMessageContentLength) {
+		messageRequests = append(messageRequests, &MessageRequest{
+			Content:    content,
+			Nonce:      newID(),
+			Masquerade: mb.masquerade,
+		})
+	}
+
+	if len(messageRequests) == 0 {
+		messageRequests = append(messageRequests, &MessageRequest{
+			Nonce:      newID(),
+			Masquerade: mb.masquerade,
+		})
+	}
+
+	messageRequests[0].Replies = mb.replies
+
+	last := messageRequests[len(messageRequests)-1]
+	last.Attachments = mb.attachments
+	last.Embeds = mb.embeds
+
+	return messageRequests
+}
+
+// Send sends the message to a channel and returns the messages sent. It
+// stops at the first request that fails.
+func (mb *MessageBuilder) Send(rb *RevoltBot, channelID string) (messages []*Message, err error) {
+	for _, messageRequest := range mb.Build() {
+		message, err := rb.SendMessage(channelID, messageRequest)
+		if err != nil {
+			return messages, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+// Reply sends the message in reply to message, in the same channel.
+func (mb *MessageBuilder) Reply(rb *RevoltBot, message *Message, mention bool) (messages []*Message, err error) {
+	return mb.ReplyTo(message, mention).Send(rb, message.ChannelID)
+}
+
+// MentionUser returns the markdown mentioning a user.
+func MentionUser(userID string) string {
+	return "<@" + userID + ">"
+}
+
+// MentionChannel returns the markdown linking to a channel.
+func MentionChannel(channelID string) string {
+	return "<#" + channelID + ">"
+}
+
+// MentionRole returns the markdown mentioning a role.
+func MentionRole(roleID string) string {
+	return "<%" + roleID + ">"
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, `*`, `\*`, `_`, `\_`, `~`, `\~`, "`", "\\`", `|`, `\|`,
+	`>`, `\>`, `<`, `\<`, `#`, `\#`, `[`, `\[`, `]`, `\]`, `(`, `\(`,
+	`)`, `\)`, `!`, `\!`, `$`, `\$`, `:`, `\:`, `-`, `\-`,
+)
+
+// EscapeMarkdown escapes text so it is shown as is, without formatting,
+// mentions or emoji.
+func EscapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// splitContent splits content into parts of at most limit characters,
+// preferably after a line, then a word, in the latter half of the part.
+// Content is never split inside a character.
+func splitContent(content string, limit int) (parts []string) {
+	for utf8.RuneCountInString(content) > limit {
+		// Byte offset of the character just past the limit.
+		end := 0
+		for i := 0; i < limit; i++ {
+			_, size := utf8.DecodeRuneInString(content[end:])
+			end += size
+		}
+
+		// Breaking earlier than half the limit would leave a short part, so
+		// the content is cut at the limit instead.
+		cut := end
+		if content[end] != '\n' && content[end] != ' ' {
+			if i := strings.LastIndexByte(content[:end], '\n'); i > 0 && i >= end/2 {
+				cut = i
+			} else if i := strings.LastIndexByte(content[:end], ' '); i > 0 && i >= end/2 {
+				cut = i
+			}
+		}
+
+		parts = append(parts, content[:cut])
+
+		// Drop the line break or space the content was split at.
+		if content[cut] == '\n' || content[cut] == ' ' {
+			cut++
+		}
+
+		content = content[cut:]
+	}
+
+	if content != "" {
+		parts = append(parts, content)
+	}
+
+	return parts
+}
@@ -0,0 +1,160 @@
+package revolt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		limit   int
+		want    []string
+	}{
+		{
+			name:    "empty",
+			content: "",
+			limit:   10,
+		},
+		{
+			name:    "exact limit",
+			content: "0123456789",
+			limit:   10,
+			want:    []string{"0123456789"},
+		},
+		{
+			name:    "hard cut",
+			content: "0123456789ab",
+			limit:   10,
+			want:    []string{"0123456789", "ab"},
+		},
+		{
+			name:    "multibyte",
+			content: "ééééé€€€€€€",
+			limit:   5,
+			want:    []string{"ééééé", "€€€€€", "€"},
+		},
+		{
+			name:    "line preferred over word",
+			content: "one two\nthree four",
+			limit:   12,
+			want:    []string{"one two", "three four"},
+		},
+		{
+			name:    "word",
+			content: "one two three",
+			limit:   10,
+			want:    []string{"one two", "three"},
+		},
+		{
+			name:    "break at limit",
+			content: "0123456789 ab",
+			limit:   10,
+			want:    []string{"0123456789", "ab"},
+		},
+		{
+			name:    "early line break ignored",
+			content: "a\nbc def ghij",
+			limit:   10,
+			want:    []string{"a\nbc def", "ghij"},
+		},
+		{
+			name:    "early word break ignored",
+			content: "a bcdefghijkl",
+			limit:   10,
+			want:    []string{"a bcdefghi", "jkl"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := splitContent(test.content, test.limit); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("splitContent(%q, %d) = %q, want %q", test.content, test.limit, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSplitContentLength(t *testing.T) {
+	contents := []string{
+		"x\n" + strings.Repeat("ab ", 1000),
+		strings.Repeat("word ", 1000),
+		strings.Repeat("line\n", 1000),
+		strings.Repeat("€", 4500),
+		strings.Repeat("a", 2000) + "\n" + strings.Repeat("b", 10),
+	}
+
+	for _, content := range contents {
+		parts := splitContent(content, MaxMessageContentLength)
+
+		for i, part := range parts {
+			n := utf8.RuneCountInString(part)
+			if n > MaxMessageContentLength || !utf8.ValidString(part) {
+				t.Errorf("part %d of %q has %d characters", i, content[:10], n)
+			}
+
+			// Only the last part may be shorter than half the limit.
+			if i < len(parts)-1 && n < MaxMessageContentLength/2 {
+				t.Errorf("part %d of %q has only %d characters", i, content[:10], n)
+			}
+		}
+	}
+}
+
+func TestMessageBuilderBuild(t *testing.T) {
+	message := &Message{ID: "message"}
+	embed := &SendableEmbed{}
+	masquerade := &Masquerade{}
+
+	mb := NewMessageBuilder().
+		Write(strings.Repeat("a", MaxMessageContentLength)).
+		Write(strings.Repeat("b", 10)).
+		ReplyTo(message, true).
+		Attach("file").
+		Embed(embed).
+		Masquerade(masquerade)
+
+	requests := mb.Build()
+	if len(requests) != 2 {
+		t.Fatalf("Build() = %d requests, want 2", len(requests))
+	}
+
+	first, last := requests[0], requests[1]
+
+	if !reflect.DeepEqual(first.Replies, []*Reply{{ID: "message", Mention: true}}) || last.Replies != nil {
+		t.Errorf("replies = %v and %v, want them on the first request", first.Replies, last.Replies)
+	}
+
+	if first.Attachments != nil || first.Embeds != nil {
+		t.Errorf("first request has attachments %v and embeds %v", first.Attachments, first.Embeds)
+	}
+
+	if !reflect.DeepEqual(last.Attachments, []string{"file"}) || len(last.Embeds) != 1 || last.Embeds[0] != embed {
+		t.Errorf("last request has attachments %v and embeds %v", last.Attachments, last.Embeds)
+	}
+
+	for i, request := range requests {
+		if request.Masquerade != masquerade {
+			t.Errorf("request %d masquerade = %v", i, request.Masquerade)
+		}
+
+		if request.Nonce == "" || (i > 0 && request.Nonce == requests[i-1].Nonce) {
+			t.Errorf("request %d nonce = %q", i, request.Nonce)
+		}
+	}
+
+	if last.Content != strings.Repeat("b", 10) {
+		t.Errorf("last content = %q", last.Content)
+	}
+}
+
+func TestMessageBuilderBuildEmpty(t *testing.T) {
+	requests := NewMessageBuilder().Attach("file").Build()
+
+	if len(requests) != 1 || requests[0].Content != "" || !reflect.DeepEqual(requests[0].Attachments, []string{"file"}) {
+		t.Errorf("Build() = %+v, want a single request with the attachment", requests)
+	}
+}
@@ -0,0 +1,257 @@
+package revolt
+
+import "sync"
+
+// Cache stores the state received from the gateway and the API. It must be
+// safe for concurrent use. Range functions stop once f returns false.
+type Cache interface {
+	GetUser(userID string) (user *User, ok bool)
+	SetUser(user *User)
+	DeleteUser(userID string)
+	RangeUsers(f func(user *User) bool)
+
+	GetGuild(guildID string) (guild *Guild, ok bool)
+	SetGuild(guild *Guild)
+	DeleteGuild(guildID string)
+	RangeGuilds(f func(guild *Guild) bool)
+
+	GetChannel(channelID string) (channel *Channel, ok bool)
+	SetChannel(channel *Channel)
+	DeleteChannel(channelID string)
+	RangeChannels(f func(channel *Channel) bool)
+
+	GetMember(guildID string, userID string) (member *GuildMember, ok bool)
+	SetMember(member *GuildMember)
+	DeleteMember(guildID string, userID string)
+	RangeMembers(f func(member *GuildMember) bool)
+
+	// Batch calls f with a Cache whose writes are applied together, which
+	// saves a write per object for backends that store state on disk. f
+	// must only use the Cache passed to it.
+	Batch(f func(c Cache))
+}
+
+// CacheFlags selects which kinds of state are cached.
+type CacheFlags uint8
+
+const (
+	CacheUsers CacheFlags = 1 << iota
+	CacheGuilds
+	CacheChannels
+	CacheMembers
+
+	CacheNone CacheFlags = 0
+	CacheAll             = CacheUsers | CacheGuilds | CacheChannels | CacheMembers
+)
+
+// MapCache is a Cache that keeps everything in memory.
+type MapCache struct {
+	usersMu sync.RWMutex
+	users   map[string]*User
+
+	guildsMu sync.RWMutex
+	guilds   map[string]*Guild
+
+	channelsMu sync.RWMutex
+	channels   map[string]*Channel
+
+	membersMu sync.RWMutex
+	members   map[string]*GuildMember
+}
+
+func NewMapCache() *MapCache {
+	return &MapCache{
+		users:    make(map[string]*User),
+		guilds:   make(map[string]*Guild),
+		channels: make(map[string]*Channel),
+		members:  make(map[string]*GuildMember),
+	}
+}
+
+func (mc *MapCache) GetUser(userID string) (user *User, ok bool) {
+	mc.usersMu.RLock()
+	user, ok = mc.users[userID]
+	mc.usersMu.RUnlock()
+
+	return user, ok
+}
+
+func (mc *MapCache) SetUser(user *User) {
+	mc.usersMu.Lock()
+	mc.users[user.ID] = user
+	mc.usersMu.Unlock()
+}
+
+func (mc *MapCache) DeleteUser(userID string) {
+	mc.usersMu.Lock()
+	delete(mc.users, userID)
+	mc.usersMu.Unlock()
+}
+
+func (mc *MapCache) RangeUsers(f func(user *User) bool) {
+	mc.usersMu.RLock()
+	users := make([]*User, 0, len(mc.users))
+	for _, u := range mc.users {
+		users = append(users, u)
+	}
+	mc.usersMu.RUnlock()
+
+	for _, u := range users {
+		if !f(u) {
+			return
+		}
+	}
+}
+
+func (mc *MapCache) GetGuild(guildID string) (guild *Guild, ok bool) {
+	mc.guildsMu.RLock()
+	guild, ok = mc.guilds[guildID]
+	mc.guildsMu.RUnlock()
+
+	return guild, ok
+}
+
+func (mc *MapCache) SetGuild(guild *Guild) {
+	mc.guildsMu.Lock()
+	mc.guilds[guild.ID] = guild
+	mc.guildsMu.Unlock()
+}
+
+func (mc *MapCache) DeleteGuild(guildID string) {
+	mc.guildsMu.Lock()
+	delete(mc.guilds, guildID)
+	mc.guildsMu.Unlock()
+}
+
+func (mc *MapCache) RangeGuilds(f func(guild *Guild) bool) {
+	mc.guildsMu.RLock()
+	guilds := make([]*Guild, 0, len(mc.guilds))
+	for _, g := range mc.guilds {
+		guilds = append(guilds, g)
+	}
+	mc.guildsMu.RUnlock()
+
+	for _, g := range guilds {
+		if !f(g) {
+			return
+		}
+	}
+}
+
+func (mc *MapCache) GetChannel(channelID string) (channel *Channel, ok bool) {
+	mc.channelsMu.RLock()
+	channel, ok = mc.channels[channelID]
+	mc.channelsMu.RUnlock()
+
+	return channel, ok
+}
+
+func (mc *MapCache) SetChannel(channel *Channel) {
+	mc.channelsMu.Lock()
+	mc.channels[channel.ID] = channel
+	mc.channelsMu.Unlock()
+}
+
+func (mc *MapCache) DeleteChannel(channelID string) {
+	mc.channelsMu.Lock()
+	delete(mc.channels, channelID)
+	mc.channelsMu.Unlock()
+}
+
+func (mc *MapCache) RangeChannels(f func(channel *Channel) bool) {
+	mc.channelsMu.RLock()
+	channels := make([]*Channel, 0, len(mc.channels))
+	for _, c := range mc.channels {
+		channels = append(channels, c)
+	}
+	mc.channelsMu.RUnlock()
+
+	for _, c := range channels {
+		if !f(c) {
+			return
+		}
+	}
+}
+
+func (mc *MapCache) GetMember(guildID string, userID string) (member *GuildMember, ok bool) {
+	mc.membersMu.RLock()
+	member, ok = mc.members[memberKey(guildID, userID)]
+	mc.membersMu.RUnlock()
+
+	return member, ok
+}
+
+func (mc *MapCache) SetMember(member *GuildMember) {
+	mc.membersMu.Lock()
+	mc.members[memberKey(member.ID.Server, member.ID.User)] = member
+	mc.membersMu.Unlock()
+}
+
+func (mc *MapCache) DeleteMember(guildID string, userID string) {
+	mc.membersMu.Lock()
+	delete(mc.members, memberKey(guildID, userID))
+	mc.membersMu.Unlock()
+}
+
+func (mc *MapCache) RangeMembers(f func(member *GuildMember) bool) {
+	mc.membersMu.RLock()
+	members := make([]*GuildMember, 0, len(mc.members))
+	for _, m := range mc.members {
+		members = append(members, m)
+	}
+	mc.membersMu.RUnlock()
+
+	for _, m := range members {
+		if !f(m) {
+			return
+		}
+	}
+}
+
+// Batch calls f with the MapCache itself, as its writes are cheap.
+func (mc *MapCache) Batch(f func(c Cache)) {
+	f(mc)
+}
+
+// filteredCache ignores the kinds of state that are not in flags.
+type filteredCache struct {
+	Cache
+
+	flags CacheFlags
+}
+
+// NewFilteredCache wraps a Cache so only the kinds of state in flags are
+// stored in it.
+func NewFilteredCache(cache Cache, flags CacheFlags) Cache {
+	return &filteredCache{Cache: cache, flags: flags}
+}
+
+func (fc *filteredCache) SetUser(user *User) {
+	if fc.flags&CacheUsers != 0 {
+		fc.Cache.SetUser(user)
+	}
+}
+
+func (fc *filteredCache) SetGuild(guild *Guild) {
+	if fc.flags&CacheGuilds != 0 {
+		fc.Cache.SetGuild(guild)
+	}
+}
+
+func (fc *filteredCache) SetChannel(channel *Channel) {
+	if fc.flags&CacheChannels != 0 {
+		fc.Cache.SetChannel(channel)
+	}
+}
+
+func (fc *filteredCache) SetMember(member *GuildMember) {
+	if fc.flags&CacheMembers != 0 {
+		fc.Cache.SetMember(member)
+	}
+}
+
+func (fc *filteredCache) Batch(f func(c Cache)) {
+	fc.Cache.Batch(func(c Cache) {
+		f(&filteredCache{Cache: c, flags: fc.flags})
+	})
+}
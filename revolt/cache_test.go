@@ -0,0 +1,211 @@
+package revolt
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// testCache runs the tests every Cache must pass.
+func testCache(t *testing.T, cache Cache) {
+	user := &User{ID: "user", Username: "someone", Avatar: &File{ID: "avatar"}}
+	guild := &Guild{
+		ID:                 "guild",
+		Owner:              "user",
+		Name:               "server",
+		Channels:           []string{"channel"},
+		Roles:              map[string]*GuildRole{"role": {Name: "role", Permissions: PermissionSet{1, 2}, Rank: 1}},
+		DefaultPermissions: PermissionSet{3, 4},
+	}
+	channel := &Channel{
+		ID:          "channel",
+		ChannelType: ChannelTypeText,
+		TextChannel: &TextChannel{
+			Server:             "guild",
+			Name:               "general",
+			DefaultPermissions: &PermissionOverride{Allow: ChannelPermissionView},
+			RolePermissions:    map[string]*PermissionOverride{"role": {Deny: ChannelPermissionSendMessage}},
+		},
+	}
+	member := &GuildMember{ID: &GuildMemberIDs{Server: "guild", User: "user"}, Nickname: "nick", Roles: []string{"role"}}
+
+	t.Run("Users", func(t *testing.T) {
+		cache.SetUser(user)
+
+		got, ok := cache.GetUser(user.ID)
+		if !ok || !reflect.DeepEqual(got, user) {
+			t.Fatalf("GetUser() = %+v, %v, want %+v", got, ok, user)
+		}
+
+		cache.DeleteUser(user.ID)
+
+		if _, ok := cache.GetUser(user.ID); ok {
+			t.Fatal("user still cached after DeleteUser")
+		}
+	})
+
+	t.Run("Guilds", func(t *testing.T) {
+		cache.SetGuild(guild)
+
+		got, ok := cache.GetGuild(guild.ID)
+		if !ok || !reflect.DeepEqual(got, guild) {
+			t.Fatalf("GetGuild() = %+v, %v, want %+v", got, ok, guild)
+		}
+
+		cache.DeleteGuild(guild.ID)
+
+		if _, ok := cache.GetGuild(guild.ID); ok {
+			t.Fatal("guild still cached after DeleteGuild")
+		}
+	})
+
+	t.Run("Channels", func(t *testing.T) {
+		cache.SetChannel(channel)
+
+		got, ok := cache.GetChannel(channel.ID)
+		if !ok || !reflect.DeepEqual(got, channel) {
+			t.Fatalf("GetChannel() = %+v, %v, want %+v", got, ok, channel)
+		}
+
+		cache.DeleteChannel(channel.ID)
+
+		if _, ok := cache.GetChannel(channel.ID); ok {
+			t.Fatal("channel still cached after DeleteChannel")
+		}
+	})
+
+	t.Run("Members", func(t *testing.T) {
+		cache.SetMember(member)
+
+		got, ok := cache.GetMember("guild", "user")
+		if !ok || !reflect.DeepEqual(got, member) {
+			t.Fatalf("GetMember() = %+v, %v, want %+v", got, ok, member)
+		}
+
+		if _, ok := cache.GetMember("other", "user"); ok {
+			t.Fatal("member found in the wrong server")
+		}
+
+		cache.DeleteMember("guild", "user")
+
+		if _, ok := cache.GetMember("guild", "user"); ok {
+			t.Fatal("member still cached after DeleteMember")
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		for _, id := range []string{"a", "b", "c"} {
+			cache.SetUser(&User{ID: id})
+		}
+
+		var ids []string
+
+		cache.RangeUsers(func(u *User) bool {
+			ids = append(ids, u.ID)
+
+			return true
+		})
+
+		sort.Strings(ids)
+
+		if !reflect.DeepEqual(ids, []string{"a", "b", "c"}) {
+			t.Fatalf("RangeUsers() visited %v", ids)
+		}
+
+		calls := 0
+
+		cache.RangeUsers(func(u *User) bool {
+			calls++
+
+			return false
+		})
+
+		if calls != 1 {
+			t.Fatalf("RangeUsers() kept going after f returned false, %d calls", calls)
+		}
+
+		// Deleting while ranging must not deadlock.
+		cache.RangeUsers(func(u *User) bool {
+			cache.DeleteUser(u.ID)
+
+			return true
+		})
+
+		cache.RangeUsers(func(u *User) bool {
+			t.Fatalf("user %q left after deleting every user", u.ID)
+
+			return false
+		})
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		cache.Batch(func(c Cache) {
+			c.SetGuild(guild)
+			c.SetChannel(channel)
+			c.SetMember(member)
+
+			if _, ok := c.GetGuild(guild.ID); !ok {
+				t.Error("guild written in a batch not visible inside it")
+			}
+
+			c.DeleteChannel(channel.ID)
+		})
+
+		if _, ok := cache.GetGuild(guild.ID); !ok {
+			t.Error("guild written in a batch not cached")
+		}
+
+		if _, ok := cache.GetMember("guild", "user"); !ok {
+			t.Error("member written in a batch not cached")
+		}
+
+		if _, ok := cache.GetChannel(channel.ID); ok {
+			t.Error("channel deleted in a batch still cached")
+		}
+	})
+}
+
+func TestMapCache(t *testing.T) {
+	testCache(t, NewMapCache())
+}
+
+func TestBoltCache(t *testing.T) {
+	bc, err := NewBoltCache(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bc.Close()
+
+	testCache(t, bc)
+}
+
+func TestFilteredCache(t *testing.T) {
+	testCache(t, NewFilteredCache(NewMapCache(), CacheAll))
+
+	cache := NewFilteredCache(NewMapCache(), CacheUsers)
+
+	cache.Batch(func(c Cache) {
+		c.SetUser(&User{ID: "user"})
+		c.SetGuild(&Guild{ID: "guild"})
+	})
+
+	if _, ok := cache.GetUser("user"); !ok {
+		t.Error("user not cached with CacheUsers")
+	}
+
+	if _, ok := cache.GetGuild("guild"); ok {
+		t.Error("guild cached without CacheGuilds")
+	}
+}
+
+func TestWithCacheFlags(t *testing.T) {
+	// The flags apply whatever the order of the options.
+	rb := NewRevoltBot("", WithCacheFlags(CacheUsers), WithCache(NewMapCache()))
+
+	rb.State.SetGuild(&Guild{ID: "guild"})
+
+	if _, ok := rb.State.GetGuild("guild"); ok {
+		t.Error("guild cached without CacheGuilds")
+	}
+}
@@ -0,0 +1,301 @@
+package revolt
+
+import (
+	"net/http"
+
+	"github.com/vmihailenco/msgpack"
+)
+
+// Types of channels that can be created in a server.
+const (
+	ChannelCreateTypeText  = "Text"
+	ChannelCreateTypeVoice = "Voice"
+)
+
+type ChannelCreateRequest struct {
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Nonce       string `json:"nonce"`
+}
+
+// ChannelEdit changes the properties of a channel. Nil fields are left
+// unchanged.
+type ChannelEdit struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// Autumn ID of the uploaded icon.
+	Icon *string `json:"icon,omitempty"`
+
+	// Field to remove from the channel: Icon or Description.
+	Remove string `json:"remove,omitempty"`
+}
+
+// FetchChannel fetches a channel and adds it to the cache.
+func (rb *RevoltBot) FetchChannel(channelID string) (channel *Channel, err error) {
+	err = rb.request(http.MethodGet, "/channels/"+channelID, nil, &channel)
+	if err != nil {
+		return nil, err
+	}
+
+	rb.State.SetChannel(channel)
+
+	return channel, nil
+}
+
+// CreateChannel creates a channel in a server. The cache is updated by the
+// ChannelCreate event that follows.
+func (rb *RevoltBot) CreateChannel(guildID string, channelCreate *ChannelCreateRequest) (channel *Channel, err error) {
+	if channelCreate.Nonce == "" {
+		channelCreate.Nonce = newID()
+	}
+
+	err = rb.request(http.MethodPost, "/servers/"+guildID+"/channels", channelCreate, &channel)
+	if err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+func (rb *RevoltBot) EditChannel(channelID string, channelEdit *ChannelEdit) (err error) {
+	return rb.request(http.MethodPatch, "/channels/"+channelID, channelEdit, nil)
+}
+
+// DeleteChannel deletes a server channel, or closes a DM or leaves a group.
+func (rb *RevoltBot) DeleteChannel(channelID string) (err error) {
+	return rb.request(http.MethodDelete, "/channels/"+channelID, nil, nil)
+}
+
+// SetChannelPermissions sets the permissions members of a role have in a
+// channel. Use "default" as the role ID to set the permissions of everyone.
+func (rb *RevoltBot) SetChannelPermissions(channelID string, roleID string, perms ChannelPermission) (err error) {
+	return rb.request(http.MethodPut, "/channels/"+channelID+"/permissions/"+roleID, struct {
+		Permissions ChannelPermission `json:"permissions"`
+	}{perms}, nil)
+}
+
+// SetChannelDefaultPermissions sets the permissions everyone has in a
+// channel.
+func (rb *RevoltBot) SetChannelDefaultPermissions(channelID string, perms ChannelPermission) (err error) {
+	return rb.SetChannelPermissions(channelID, "default", perms)
+}
+
+// channelHeader holds the fields every type of channel has.
+type channelHeader struct {
+	ID          string `json:"_id"`
+	ChannelType string `json:"channel_type"`
+	Nonce       string `json:"nonce,omitempty"`
+}
+
+// decode decodes data on top of the channel, into the field matching its
+// type. Partial updates do not include channel_type, so the type of the
+// channel already decoded is kept.
+func (c *Channel) decode(cd codec, data []byte) (err error) {
+	header := channelHeader{c.ID, c.ChannelType, c.Nonce}
+
+	err = cd.Unmarshal(data, &header)
+	if err != nil {
+		return err
+	}
+
+	c.ID, c.ChannelType, c.Nonce = header.ID, header.ChannelType, header.Nonce
+
+	switch c.ChannelType {
+	case ChannelTypeSavedMessages:
+		if c.SavedMessages == nil {
+			c.SavedMessages = &SavedMessages{}
+		}
+
+		return cd.Unmarshal(data, c.SavedMessages)
+	case ChannelTypeDirectMessage:
+		if c.DirectMessage == nil {
+			c.DirectMessage = &DirectMessage{}
+		}
+
+		return cd.Unmarshal(data, c.DirectMessage)
+	case ChannelTypeGroup:
+		if c.Group == nil {
+			c.Group = &Group{}
+		}
+
+		return cd.Unmarshal(data, c.Group)
+	case ChannelTypeText:
+		if c.TextChannel == nil {
+			c.TextChannel = &TextChannel{}
+		}
+
+		return cd.Unmarshal(data, c.TextChannel)
+	case ChannelTypeVoice:
+		if c.VoiceChannel == nil {
+			c.VoiceChannel = &VoiceChannel{}
+		}
+
+		return cd.Unmarshal(data, c.VoiceChannel)
+	}
+
+	return nil
+}
+
+func (c *Channel) UnmarshalJSON(data []byte) (err error) {
+	return c.decode(jsonCodec{}, data)
+}
+
+func (c *Channel) DecodeMsgpack(dec *msgpack.Decoder) (err error) {
+	raw, err := reencodeMsgpack(dec)
+	if err != nil {
+		return err
+	}
+
+	return c.decode(msgpackCodec{}, raw)
+}
+
+// MarshalJSON encodes the channel as a single object, the way the API
+// sends it.
+func (c *Channel) MarshalJSON() (data []byte, err error) {
+	data, err = json.Marshal(channelHeader{c.ID, c.ChannelType, c.Nonce})
+	if err != nil {
+		return nil, err
+	}
+
+	var fields interface{}
+
+	switch {
+	case c.SavedMessages != nil:
+		fields = c.SavedMessages
+	case c.DirectMessage != nil:
+		fields = c.DirectMessage
+	case c.Group != nil:
+		fields = c.Group
+	case c.TextChannel != nil:
+		fields = c.TextChannel
+	case c.VoiceChannel != nil:
+		fields = c.VoiceChannel
+	default:
+		return data, nil
+	}
+
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) <= 2 {
+		return data, nil
+	}
+
+	// Join both objects by replacing the closing brace of the header.
+	return append(append(data[:len(data)-1], ','), body[1:]...), nil
+}
+
+// reencodeMsgpack reads the next value from dec and encodes it again, so it
+// can be decoded more than once.
+func reencodeMsgpack(dec *msgpack.Decoder) (raw []byte, err error) {
+	v, err := dec.DecodeInterfaceLoose()
+	if err != nil {
+		return nil, err
+	}
+
+	return msgpack.Marshal(v)
+}
+
+// UnmarshalJSON decodes the event itself, as the decoders promoted from the
+// embedded *Channel would be called on a nil pointer.
+func (o *ChannelCreate) UnmarshalJSON(data []byte) (err error) {
+	return o.decode(jsonCodec{}, data)
+}
+
+func (o *ChannelCreate) DecodeMsgpack(dec *msgpack.Decoder) (err error) {
+	raw, err := reencodeMsgpack(dec)
+	if err != nil {
+		return err
+	}
+
+	return o.decode(msgpackCodec{}, raw)
+}
+
+func (o *ChannelCreate) decode(cd codec, data []byte) (err error) {
+	err = cd.Unmarshal(data, &o.SentBase)
+	if err != nil {
+		return err
+	}
+
+	o.Channel = &Channel{}
+
+	return o.Channel.decode(cd, data)
+}
+
+// GuildID returns the ID of the server the channel belongs to, or an empty
+// string if it is not a server channel.
+func (c *Channel) GuildID() string {
+	switch {
+	case c.TextChannel != nil:
+		return c.TextChannel.Server
+	case c.VoiceChannel != nil:
+		return c.VoiceChannel.Server
+	}
+
+	return ""
+}
+
+// Name returns the name of a group or server channel.
+func (c *Channel) Name() string {
+	switch {
+	case c.Group != nil:
+		return c.Group.Name
+	case c.TextChannel != nil:
+		return c.TextChannel.Name
+	case c.VoiceChannel != nil:
+		return c.VoiceChannel.Name
+	}
+
+	return ""
+}
+
+// IsDM reports if the channel is a direct message between two users.
+func (c *Channel) IsDM() bool {
+	return c.DirectMessage != nil
+}
+
+// Recipients returns the IDs of the users that can see the channel if it is
+// not a server channel.
+func (c *Channel) Recipients() []string {
+	switch {
+	case c.SavedMessages != nil:
+		return []string{c.SavedMessages.User}
+	case c.DirectMessage != nil:
+		return c.DirectMessage.Recipients
+	case c.Group != nil:
+		return c.Group.Recipients
+	}
+
+	return nil
+}
+
+// LastMessageID returns the ID of the last message sent in the channel, if
+// known.
+func (c *Channel) LastMessageID() string {
+	switch {
+	case c.DirectMessage != nil:
+		return c.DirectMessage.LastMessageID
+	case c.Group != nil:
+		return c.Group.LastMessageID
+	case c.TextChannel != nil:
+		return c.TextChannel.LastMessageID
+	}
+
+	return ""
+}
+
+// permissionOverrides returns the permission overrides of a server channel.
+func (c *Channel) permissionOverrides() (defaults *PermissionOverride, roles map[string]*PermissionOverride) {
+	switch {
+	case c.TextChannel != nil:
+		return c.TextChannel.DefaultPermissions, c.TextChannel.RolePermissions
+	case c.VoiceChannel != nil:
+		return c.VoiceChannel.DefaultPermissions, c.VoiceChannel.RolePermissions
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,78 @@
+package revolt
+
+import (
+	"bytes"
+
+	"github.com/vmihailenco/msgpack"
+	"nhooyr.io/websocket"
+)
+
+// codec encodes and decodes gateway frames in the format requested when
+// dialing the gateway.
+type codec interface {
+	// Format returns the value of the format query parameter.
+	Format() string
+
+	// MessageType returns the websocket message type frames are sent as.
+	MessageType() websocket.MessageType
+
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Format() string {
+	return "json"
+}
+
+func (jsonCodec) MessageType() websocket.MessageType {
+	return websocket.MessageText
+}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// msgpackCodec uses the json struct tags so the same event structs can be
+// used with either format.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Format() string {
+	return "msgpack"
+}
+
+func (msgpackCodec) MessageType() websocket.MessageType {
+	return websocket.MessageBinary
+}
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+
+	err := msgpack.NewEncoder(&b).UseJSONTag(true).Encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return msgpack.NewDecoder(bytes.NewReader(data)).UseJSONTag(true).Decode(v)
+}
+
+// eventType returns the type of a gateway frame.
+func eventType(c codec, data []byte) (messageType string, err error) {
+	o := SentBase{}
+
+	err = c.Unmarshal(data, &o)
+	if err != nil {
+		return "", err
+	}
+
+	return o.Type, nil
+}
@@ -0,0 +1,234 @@
+package revolt
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/vmihailenco/msgpack"
+)
+
+// toMsgpack converts a JSON frame to the frame the gateway sends when the
+// msgpack format is requested.
+func toMsgpack(t *testing.T, frame string) []byte {
+	t.Helper()
+
+	var v interface{}
+
+	if err := json.Unmarshal([]byte(frame), &v); err != nil {
+		t.Fatalf("invalid fixture %s: %v", frame, err)
+	}
+
+	data, err := msgpack.Marshal(integers(v))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+// integers replaces the whole float64s decoded from JSON with integers, as
+// they would be sent in msgpack.
+func integers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if v == math.Trunc(v) {
+			return int64(v)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = integers(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = integers(v[k])
+		}
+	}
+
+	return v
+}
+
+// decodeFrame decodes a JSON frame with c, the way the read loop does.
+func decodeFrame(t *testing.T, c codec, frame string) interface{} {
+	t.Helper()
+
+	data := []byte(frame)
+	if _, ok := c.(msgpackCodec); ok {
+		data = toMsgpack(t, frame)
+	}
+
+	mType, err := eventType(c, data)
+	if err != nil {
+		t.Fatalf("eventType(%s) with %s: %v", frame, c.Format(), err)
+	}
+
+	decode, ok := eventDecoders[mType]
+	if !ok {
+		t.Fatalf("no decoder for %q", mType)
+	}
+
+	event, err := decode(c, data)
+	if err != nil {
+		t.Fatalf("decoding %s with %s: %v", frame, c.Format(), err)
+	}
+
+	return event
+}
+
+var codecs = []codec{jsonCodec{}, msgpackCodec{}}
+
+func TestCodecEvents(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame string
+		check func(t *testing.T, event interface{})
+	}{
+		{
+			name:  "Ready",
+			frame: readyFixture,
+			check: func(t *testing.T, event interface{}) {
+				o := event.(Ready)
+				if len(o.Users) != 2 || len(o.Guilds) != 1 || len(o.Channels) != 1 || len(o.Members) != 2 {
+					t.Fatalf("Ready = %+v", o)
+				}
+
+				if c := o.Channels[0]; c.TextChannel == nil || c.TextChannel.Server != "guild" {
+					t.Errorf("channel = %+v", c)
+				}
+
+				if r := o.Guilds[0].Roles["role"]; r == nil || r.Rank != 1 {
+					t.Errorf("role = %+v", r)
+				}
+
+				if m := o.Members[1]; m.ID.User != "user" || m.Nickname != "nick" {
+					t.Errorf("member = %+v", m)
+				}
+			},
+		},
+		{
+			name:  "Pong",
+			frame: `{"type": "Pong", "time": 1650000000000}`,
+			check: func(t *testing.T, event interface{}) {
+				if o := event.(Pong); o.Time != 1650000000000 {
+					t.Errorf("Pong time = %d", o.Time)
+				}
+			},
+		},
+		{
+			name:  "Error",
+			frame: `{"type": "Error", "error": "InvalidSession"}`,
+			check: func(t *testing.T, event interface{}) {
+				if o := event.(Error); !o.Err().Fatal() {
+					t.Errorf("%+v is not fatal", o)
+				}
+			},
+		},
+		{
+			name:  "ChannelCreate group",
+			frame: `{"type": "ChannelCreate", "_id": "group", "channel_type": "Group", "name": "friends", "owner": "user", "recipients": ["user", "bot"]}`,
+			check: func(t *testing.T, event interface{}) {
+				o := event.(ChannelCreate)
+				if o.Channel == nil || o.ID != "group" || o.Group == nil || !reflect.DeepEqual(o.Group.Recipients, []string{"user", "bot"}) {
+					t.Errorf("ChannelCreate = %+v", o.Channel)
+				}
+			},
+		},
+		{
+			name:  "ChannelCreate voice",
+			frame: `{"type": "ChannelCreate", "_id": "voice", "channel_type": "VoiceChannel", "server": "guild", "name": "talk"}`,
+			check: func(t *testing.T, event interface{}) {
+				if o := event.(ChannelCreate); o.VoiceChannel == nil || o.VoiceChannel.Name != "talk" {
+					t.Errorf("ChannelCreate = %+v", o.Channel)
+				}
+			},
+		},
+		{
+			name:  "ServerMemberUpdate",
+			frame: `{"type": "ServerMemberUpdate", "id": {"server": "guild", "user": "user"}, "data": {"nickname": "nick"}, "clear": "Avatar"}`,
+			check: func(t *testing.T, event interface{}) {
+				o := event.(ServerMemberUpdate)
+				if o.ID.User != "user" || o.Member.Nickname != "nick" || o.Clear != "Avatar" {
+					t.Errorf("ServerMemberUpdate = %+v", o)
+				}
+			},
+		},
+		{
+			name:  "ServerRoleUpdate",
+			frame: `{"type": "ServerRoleUpdate", "id": "guild", "role_id": "role", "data": {"name": "role", "colour": "red"}}`,
+			check: func(t *testing.T, event interface{}) {
+				if o := event.(ServerRoleUpdate); o.RoleID != "role" || o.Role.Colour != "red" {
+					t.Errorf("ServerRoleUpdate = %+v", o)
+				}
+			},
+		},
+		{
+			name:  "UserUpdate",
+			frame: `{"type": "UserUpdate", "id": "user", "data": {"status": {"text": "hi"}}}`,
+			check: func(t *testing.T, event interface{}) {
+				if o := event.(UserUpdate); o.UserID != "user" || o.Data.Status.CustomStatus != "hi" {
+					t.Errorf("UserUpdate = %+v", o)
+				}
+			},
+		},
+		{
+			name:  "ChannelStartTyping",
+			frame: `{"type": "ChannelStartTyping", "id": "general", "user": "user"}`,
+			check: func(t *testing.T, event interface{}) {
+				if o := event.(ChannelStartTyping); o.ChannelID != "general" || o.UserID != "user" {
+					t.Errorf("ChannelStartTyping = %+v", o)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var events []interface{}
+
+			for _, c := range codecs {
+				t.Run(c.Format(), func(t *testing.T) {
+					event := decodeFrame(t, c, test.frame)
+					test.check(t, event)
+
+					events = append(events, event)
+				})
+			}
+
+			if len(events) == 2 && !reflect.DeepEqual(events[0], events[1]) {
+				t.Errorf("codecs decoded different events:\njson:    %+v\nmsgpack: %+v", events[0], events[1])
+			}
+		})
+	}
+}
+
+func TestCodecSentEvents(t *testing.T) {
+	token := "token"
+
+	events := []interface{}{
+		Authenticate{SentBase: SentBase{EventTypeAuthenticate}, Token: &token},
+		Ping{SentBase: SentBase{EventTypePing}, Time: 1650000000000},
+		BeginTyping{SentBase: SentBase{EventTypeBeginTyping}, Channel: "general"},
+	}
+
+	for _, c := range codecs {
+		for _, event := range events {
+			data, err := c.Marshal(event)
+			if err != nil {
+				t.Fatalf("%s: Marshal(%+v): %v", c.Format(), event, err)
+			}
+
+			if mType, err := eventType(c, data); err != nil || mType != reflect.ValueOf(event).FieldByName("Type").String() {
+				t.Errorf("%s: eventType() = %q, %v", c.Format(), mType, err)
+			}
+
+			got := reflect.New(reflect.TypeOf(event))
+			if err := c.Unmarshal(data, got.Interface()); err != nil {
+				t.Fatalf("%s: Unmarshal(): %v", c.Format(), err)
+			}
+
+			if !reflect.DeepEqual(got.Elem().Interface(), event) {
+				t.Errorf("%s: round trip of %+v gave %+v", c.Format(), event, got.Elem().Interface())
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package revolt
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+)
+
+var ErrNotConnected = errors.New("not connected to the gateway")
+
+// ErrNoWebsocketURL is returned by Start when the node info of an instance
+// other than the official one does not include the URL of its gateway.
+var ErrNoWebsocketURL = errors.New("instance did not advertise a websocket URL")
+
+// ErrNoAutumnURL is returned when uploading a file to an instance whose
+// file server is not known.
+var ErrNoAutumnURL = errors.New("instance has no known file server")
+
+// ErrNotCached is returned when an object needed to answer a request is
+// neither cached nor available from the API.
+var ErrNotCached = errors.New("not found in the cache")
+
+// Errors sent by the gateway in Error frames. Use errors.Is to compare
+// against these.
+var (
+	ErrLabelMe               = &GatewayError{Type: "LabelMe"}
+	ErrInternalError         = &GatewayError{Type: "InternalError"}
+	ErrInvalidSession        = &GatewayError{Type: "InvalidSession"}
+	ErrOnboardingNotFinished = &GatewayError{Type: "OnboardingNotFinished"}
+	ErrAlreadyAuthenticated  = &GatewayError{Type: "AlreadyAuthenticated"}
+	ErrMalformedData         = &GatewayError{Type: "MalformedData"}
+)
+
+// GatewayError is an error received from the gateway.
+type GatewayError struct {
+	Type string
+}
+
+func (e *GatewayError) Error() string {
+	return "gateway error: " + e.Type
+}
+
+// Is reports if target is a GatewayError of the same type.
+func (e *GatewayError) Is(target error) bool {
+	t, ok := target.(*GatewayError)
+
+	return ok && t.Type == e.Type
+}
+
+// Fatal reports if the error can not be recovered from by reconnecting,
+// such as an invalid token.
+func (e *GatewayError) Fatal() bool {
+	switch e.Type {
+	case ErrInvalidSession.Type, ErrOnboardingNotFinished.Type:
+		return true
+	default:
+		return false
+	}
+}
+
+// Err returns the error received in the Error frame.
+func (o Error) Err() *GatewayError {
+	return &GatewayError{Type: o.Error}
+}
+
+// ShutdownError is returned by Shutdown when events were still being
+// dispatched once its context was done.
+type ShutdownError struct {
+	// Number of unfinished events by event type.
+	Dropped map[string]int
+
+	err error
+}
+
+func (e *ShutdownError) Error() string {
+	var n int
+	for _, c := range e.Dropped {
+		n += c
+	}
+
+	return "shutdown: " + e.err.Error() + " with " + strconv.Itoa(n) + " events still dispatching"
+}
+
+func (e *ShutdownError) Unwrap() error {
+	return e.err
+}
+
+// Error types returned by the API. Use errors.Is to compare an *APIError
+// against these.
+var (
+	ErrAPILabelMe                      = &APIError{Type: "LabelMe"}
+	ErrAPIInternalError                = &APIError{Type: "InternalError"}
+	ErrAPIDatabaseError                = &APIError{Type: "DatabaseError"}
+	ErrAPINotFound                     = &APIError{Type: "NotFound"}
+	ErrAPINoEffect                     = &APIError{Type: "NoEffect"}
+	ErrAPIFailedValidation             = &APIError{Type: "FailedValidation"}
+	ErrAPIInvalidOperation             = &APIError{Type: "InvalidOperation"}
+	ErrAPIInvalidCredentials           = &APIError{Type: "InvalidCredentials"}
+	ErrAPIMissingPermission            = &APIError{Type: "MissingPermission"}
+	ErrAPIMissingUserPermission        = &APIError{Type: "MissingUserPermission"}
+	ErrAPINotElevated                  = &APIError{Type: "NotElevated"}
+	ErrAPIUnknownUser                  = &APIError{Type: "UnknownUser"}
+	ErrAPIUnknownChannel               = &APIError{Type: "UnknownChannel"}
+	ErrAPIUnknownServer                = &APIError{Type: "UnknownServer"}
+	ErrAPIUnknownMessage               = &APIError{Type: "UnknownMessage"}
+	ErrAPIUnknownAttachment            = &APIError{Type: "UnknownAttachment"}
+	ErrAPICannotEditMessage            = &APIError{Type: "CannotEditMessage"}
+	ErrAPIEmptyMessage                 = &APIError{Type: "EmptyMessage"}
+	ErrAPITooManyAttachments           = &APIError{Type: "TooManyAttachments"}
+	ErrAPITooManyReplies               = &APIError{Type: "TooManyReplies"}
+	ErrAPIDuplicateNonce               = &APIError{Type: "DuplicateNonce"}
+	ErrAPIInvalidRole                  = &APIError{Type: "InvalidRole"}
+	ErrAPIBanned                       = &APIError{Type: "Banned"}
+	ErrAPICannotRemoveYourself         = &APIError{Type: "CannotRemoveYourself"}
+	ErrAPIBlocked                      = &APIError{Type: "Blocked"}
+	ErrAPIBlockedByOther               = &APIError{Type: "BlockedByOther"}
+	ErrAPIIsBot                        = &APIError{Type: "IsBot"}
+	ErrAPICannotGiveMissingPermissions = &APIError{Type: "CannotGiveMissingPermissions"}
+)
+
+// APIError is returned for REST responses with an error status.
+type APIError struct {
+	StatusCode int
+
+	// Type is the error type sent by the API, such as NotFound. It is empty
+	// if the response did not include one.
+	Type string
+
+	// Any other fields sent with the error, such as the permission of a
+	// MissingPermission error.
+	Fields map[string]interface{}
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	e := &APIError{StatusCode: resp.StatusCode}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil || json.Unmarshal(body, &e.Fields) != nil {
+		e.Fields = nil
+
+		return e
+	}
+
+	if t, ok := e.Fields["type"].(string); ok {
+		e.Type = t
+		delete(e.Fields, "type")
+	}
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	if e.Type == "" {
+		return "api error: " + strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode)
+	}
+
+	return "api error: " + strconv.Itoa(e.StatusCode) + " " + e.Type
+}
+
+// Is reports if target is an APIError of the same type.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+
+	return ok && t.Type == e.Type
+}
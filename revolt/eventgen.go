@@ -0,0 +1,121 @@
+//go:build ignore
+// +build ignore
+
+// eventgen generates eventhandlers.go from the event list in events.txt.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+type event struct {
+	Name    string
+	Struct  string
+	Handler string
+	Receive bool
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by eventgen.go from events.txt; DO NOT EDIT.
+
+package revolt
+
+// Gateway event types.
+const (
+{{- range .}}
+	EventType{{.Name}} = "{{.Name}}"
+{{- end}}
+)
+
+// eventDecoders decodes each received gateway event type into its struct.
+var eventDecoders = map[string]func(c codec, data []byte) (interface{}, error){
+{{- range .}}{{if .Receive}}
+	EventType{{.Name}}: func(c codec, data []byte) (interface{}, error) {
+		o := {{.Struct}}{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+{{- end}}{{end}}
+}
+{{range .}}{{if .Receive}}
+type {{.Handler}} func(*RevoltBot, {{.Struct}})
+
+func (eh {{.Handler}}) Type() string {
+	return EventType{{.Name}}
+}
+
+func (eh {{.Handler}}) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.({{.Struct}}); ok {
+		eh(rb, t)
+	}
+}
+{{end}}{{end}}
+func handlerForInterface(handler interface{}) EventHandler {
+	switch v := handler.(type) {
+	case func(*RevoltBot, interface{}):
+		return interfaceEventHandler(v)
+{{- range .}}{{if .Receive}}
+	case func(*RevoltBot, {{.Struct}}):
+		return {{.Handler}}(v)
+{{- end}}{{end}}
+	}
+
+	return nil
+}
+`))
+
+func main() {
+	f, err := os.Open("events.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	var events []event
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 || (fields[2] != "send" && fields[2] != "receive") {
+			log.Fatalf("invalid event line %q", line)
+		}
+
+		events = append(events, event{
+			Name:    fields[0],
+			Struct:  fields[1],
+			Handler: strings.ToLower(fields[1][:1]) + fields[1][1:] + "EventHandler",
+			Receive: fields[2] == "receive",
+		})
+	}
+
+	if err = scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	var b bytes.Buffer
+
+	if err = tmpl.Execute(&b, events); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = ioutil.WriteFile("eventhandlers.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,528 @@
+// Code generated by eventgen.go from events.txt; DO NOT EDIT.
+
+package revolt
+
+// Gateway event types.
+const (
+	EventTypeAuthenticate       = "Authenticate"
+	EventTypeBeginTyping        = "BeginTyping"
+	EventTypeEndTyping          = "EndTyping"
+	EventTypePing               = "Ping"
+	EventTypeError              = "Error"
+	EventTypeAuthenticated      = "Authenticated"
+	EventTypePong               = "Pong"
+	EventTypeReady              = "Ready"
+	EventTypeMessage            = "Message"
+	EventTypeMessageUpdate      = "MessageUpdate"
+	EventTypeMessageDelete      = "MessageDelete"
+	EventTypeChannelCreate      = "ChannelCreate"
+	EventTypeChannelUpdate      = "ChannelUpdate"
+	EventTypeChannelDelete      = "ChannelDelete"
+	EventTypeChannelGroupJoin   = "ChannelGroupJoin"
+	EventTypeChannelGroupLeave  = "ChannelGroupLeave"
+	EventTypeChannelStartTyping = "ChannelStartTyping"
+	EventTypeChannelStopTyping  = "ChannelStopTyping"
+	EventTypeChannelAck         = "ChannelAck"
+	EventTypeServerUpdate       = "ServerUpdate"
+	EventTypeServerDelete       = "ServerDelete"
+	EventTypeServerMemberUpdate = "ServerMemberUpdate"
+	EventTypeServerMemberJoin   = "ServerMemberJoin"
+	EventTypeServerMemberLeave  = "ServerMemberLeave"
+	EventTypeServerRoleUpdate   = "ServerRoleUpdate"
+	EventTypeServerRoleDelete   = "ServerRoleDelete"
+	EventTypeUserUpdate         = "UserUpdate"
+	EventTypeUserRelationship   = "UserRelationship"
+)
+
+// eventDecoders decodes each received gateway event type into its struct.
+var eventDecoders = map[string]func(c codec, data []byte) (interface{}, error){
+	EventTypeError: func(c codec, data []byte) (interface{}, error) {
+		o := Error{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeAuthenticated: func(c codec, data []byte) (interface{}, error) {
+		o := Authenticated{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypePong: func(c codec, data []byte) (interface{}, error) {
+		o := Pong{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeReady: func(c codec, data []byte) (interface{}, error) {
+		o := Ready{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeMessage: func(c codec, data []byte) (interface{}, error) {
+		o := MessageCreate{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeMessageUpdate: func(c codec, data []byte) (interface{}, error) {
+		o := MessageUpdate{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeMessageDelete: func(c codec, data []byte) (interface{}, error) {
+		o := MessageDelete{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeChannelCreate: func(c codec, data []byte) (interface{}, error) {
+		o := ChannelCreate{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeChannelUpdate: func(c codec, data []byte) (interface{}, error) {
+		o := ChannelUpdate{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeChannelDelete: func(c codec, data []byte) (interface{}, error) {
+		o := ChannelDelete{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeChannelGroupJoin: func(c codec, data []byte) (interface{}, error) {
+		o := ChannelGroupJoin{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeChannelGroupLeave: func(c codec, data []byte) (interface{}, error) {
+		o := ChannelGroupLeave{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeChannelStartTyping: func(c codec, data []byte) (interface{}, error) {
+		o := ChannelStartTyping{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeChannelStopTyping: func(c codec, data []byte) (interface{}, error) {
+		o := ChannelStopTyping{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeChannelAck: func(c codec, data []byte) (interface{}, error) {
+		o := ChannelAck{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeServerUpdate: func(c codec, data []byte) (interface{}, error) {
+		o := ServerUpdate{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeServerDelete: func(c codec, data []byte) (interface{}, error) {
+		o := ServerDelete{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeServerMemberUpdate: func(c codec, data []byte) (interface{}, error) {
+		o := ServerMemberUpdate{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeServerMemberJoin: func(c codec, data []byte) (interface{}, error) {
+		o := ServerMemberJoin{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeServerMemberLeave: func(c codec, data []byte) (interface{}, error) {
+		o := ServerMemberLeave{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeServerRoleUpdate: func(c codec, data []byte) (interface{}, error) {
+		o := ServerRoleUpdate{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeServerRoleDelete: func(c codec, data []byte) (interface{}, error) {
+		o := ServerRoleDelete{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeUserUpdate: func(c codec, data []byte) (interface{}, error) {
+		o := UserUpdate{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+	EventTypeUserRelationship: func(c codec, data []byte) (interface{}, error) {
+		o := UserRelationship{}
+		err := c.Unmarshal(data, &o)
+
+		return o, err
+	},
+}
+
+type errorEventHandler func(*RevoltBot, Error)
+
+func (eh errorEventHandler) Type() string {
+	return EventTypeError
+}
+
+func (eh errorEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(Error); ok {
+		eh(rb, t)
+	}
+}
+
+type authenticatedEventHandler func(*RevoltBot, Authenticated)
+
+func (eh authenticatedEventHandler) Type() string {
+	return EventTypeAuthenticated
+}
+
+func (eh authenticatedEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(Authenticated); ok {
+		eh(rb, t)
+	}
+}
+
+type pongEventHandler func(*RevoltBot, Pong)
+
+func (eh pongEventHandler) Type() string {
+	return EventTypePong
+}
+
+func (eh pongEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(Pong); ok {
+		eh(rb, t)
+	}
+}
+
+type readyEventHandler func(*RevoltBot, Ready)
+
+func (eh readyEventHandler) Type() string {
+	return EventTypeReady
+}
+
+func (eh readyEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(Ready); ok {
+		eh(rb, t)
+	}
+}
+
+type messageCreateEventHandler func(*RevoltBot, MessageCreate)
+
+func (eh messageCreateEventHandler) Type() string {
+	return EventTypeMessage
+}
+
+func (eh messageCreateEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(MessageCreate); ok {
+		eh(rb, t)
+	}
+}
+
+type messageUpdateEventHandler func(*RevoltBot, MessageUpdate)
+
+func (eh messageUpdateEventHandler) Type() string {
+	return EventTypeMessageUpdate
+}
+
+func (eh messageUpdateEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(MessageUpdate); ok {
+		eh(rb, t)
+	}
+}
+
+type messageDeleteEventHandler func(*RevoltBot, MessageDelete)
+
+func (eh messageDeleteEventHandler) Type() string {
+	return EventTypeMessageDelete
+}
+
+func (eh messageDeleteEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(MessageDelete); ok {
+		eh(rb, t)
+	}
+}
+
+type channelCreateEventHandler func(*RevoltBot, ChannelCreate)
+
+func (eh channelCreateEventHandler) Type() string {
+	return EventTypeChannelCreate
+}
+
+func (eh channelCreateEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ChannelCreate); ok {
+		eh(rb, t)
+	}
+}
+
+type channelUpdateEventHandler func(*RevoltBot, ChannelUpdate)
+
+func (eh channelUpdateEventHandler) Type() string {
+	return EventTypeChannelUpdate
+}
+
+func (eh channelUpdateEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ChannelUpdate); ok {
+		eh(rb, t)
+	}
+}
+
+type channelDeleteEventHandler func(*RevoltBot, ChannelDelete)
+
+func (eh channelDeleteEventHandler) Type() string {
+	return EventTypeChannelDelete
+}
+
+func (eh channelDeleteEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ChannelDelete); ok {
+		eh(rb, t)
+	}
+}
+
+type channelGroupJoinEventHandler func(*RevoltBot, ChannelGroupJoin)
+
+func (eh channelGroupJoinEventHandler) Type() string {
+	return EventTypeChannelGroupJoin
+}
+
+func (eh channelGroupJoinEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ChannelGroupJoin); ok {
+		eh(rb, t)
+	}
+}
+
+type channelGroupLeaveEventHandler func(*RevoltBot, ChannelGroupLeave)
+
+func (eh channelGroupLeaveEventHandler) Type() string {
+	return EventTypeChannelGroupLeave
+}
+
+func (eh channelGroupLeaveEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ChannelGroupLeave); ok {
+		eh(rb, t)
+	}
+}
+
+type channelStartTypingEventHandler func(*RevoltBot, ChannelStartTyping)
+
+func (eh channelStartTypingEventHandler) Type() string {
+	return EventTypeChannelStartTyping
+}
+
+func (eh channelStartTypingEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ChannelStartTyping); ok {
+		eh(rb, t)
+	}
+}
+
+type channelStopTypingEventHandler func(*RevoltBot, ChannelStopTyping)
+
+func (eh channelStopTypingEventHandler) Type() string {
+	return EventTypeChannelStopTyping
+}
+
+func (eh channelStopTypingEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ChannelStopTyping); ok {
+		eh(rb, t)
+	}
+}
+
+type channelAckEventHandler func(*RevoltBot, ChannelAck)
+
+func (eh channelAckEventHandler) Type() string {
+	return EventTypeChannelAck
+}
+
+func (eh channelAckEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ChannelAck); ok {
+		eh(rb, t)
+	}
+}
+
+type serverUpdateEventHandler func(*RevoltBot, ServerUpdate)
+
+func (eh serverUpdateEventHandler) Type() string {
+	return EventTypeServerUpdate
+}
+
+func (eh serverUpdateEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ServerUpdate); ok {
+		eh(rb, t)
+	}
+}
+
+type serverDeleteEventHandler func(*RevoltBot, ServerDelete)
+
+func (eh serverDeleteEventHandler) Type() string {
+	return EventTypeServerDelete
+}
+
+func (eh serverDeleteEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ServerDelete); ok {
+		eh(rb, t)
+	}
+}
+
+type serverMemberUpdateEventHandler func(*RevoltBot, ServerMemberUpdate)
+
+func (eh serverMemberUpdateEventHandler) Type() string {
+	return EventTypeServerMemberUpdate
+}
+
+func (eh serverMemberUpdateEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ServerMemberUpdate); ok {
+		eh(rb, t)
+	}
+}
+
+type serverMemberJoinEventHandler func(*RevoltBot, ServerMemberJoin)
+
+func (eh serverMemberJoinEventHandler) Type() string {
+	return EventTypeServerMemberJoin
+}
+
+func (eh serverMemberJoinEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ServerMemberJoin); ok {
+		eh(rb, t)
+	}
+}
+
+type serverMemberLeaveEventHandler func(*RevoltBot, ServerMemberLeave)
+
+func (eh serverMemberLeaveEventHandler) Type() string {
+	return EventTypeServerMemberLeave
+}
+
+func (eh serverMemberLeaveEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ServerMemberLeave); ok {
+		eh(rb, t)
+	}
+}
+
+type serverRoleUpdateEventHandler func(*RevoltBot, ServerRoleUpdate)
+
+func (eh serverRoleUpdateEventHandler) Type() string {
+	return EventTypeServerRoleUpdate
+}
+
+func (eh serverRoleUpdateEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ServerRoleUpdate); ok {
+		eh(rb, t)
+	}
+}
+
+type serverRoleDeleteEventHandler func(*RevoltBot, ServerRoleDelete)
+
+func (eh serverRoleDeleteEventHandler) Type() string {
+	return EventTypeServerRoleDelete
+}
+
+func (eh serverRoleDeleteEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(ServerRoleDelete); ok {
+		eh(rb, t)
+	}
+}
+
+type userUpdateEventHandler func(*RevoltBot, UserUpdate)
+
+func (eh userUpdateEventHandler) Type() string {
+	return EventTypeUserUpdate
+}
+
+func (eh userUpdateEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(UserUpdate); ok {
+		eh(rb, t)
+	}
+}
+
+type userRelationshipEventHandler func(*RevoltBot, UserRelationship)
+
+func (eh userRelationshipEventHandler) Type() string {
+	return EventTypeUserRelationship
+}
+
+func (eh userRelationshipEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	if t, ok := i.(UserRelationship); ok {
+		eh(rb, t)
+	}
+}
+
+func handlerForInterface(handler interface{}) EventHandler {
+	switch v := handler.(type) {
+	case func(*RevoltBot, interface{}):
+		return interfaceEventHandler(v)
+	case func(*RevoltBot, Error):
+		return errorEventHandler(v)
+	case func(*RevoltBot, Authenticated):
+		return authenticatedEventHandler(v)
+	case func(*RevoltBot, Pong):
+		return pongEventHandler(v)
+	case func(*RevoltBot, Ready):
+		return readyEventHandler(v)
+	case func(*RevoltBot, MessageCreate):
+		return messageCreateEventHandler(v)
+	case func(*RevoltBot, MessageUpdate):
+		return messageUpdateEventHandler(v)
+	case func(*RevoltBot, MessageDelete):
+		return messageDeleteEventHandler(v)
+	case func(*RevoltBot, ChannelCreate):
+		return channelCreateEventHandler(v)
+	case func(*RevoltBot, ChannelUpdate):
+		return channelUpdateEventHandler(v)
+	case func(*RevoltBot, ChannelDelete):
+		return channelDeleteEventHandler(v)
+	case func(*RevoltBot, ChannelGroupJoin):
+		return channelGroupJoinEventHandler(v)
+	case func(*RevoltBot, ChannelGroupLeave):
+		return channelGroupLeaveEventHandler(v)
+	case func(*RevoltBot, ChannelStartTyping):
+		return channelStartTypingEventHandler(v)
+	case func(*RevoltBot, ChannelStopTyping):
+		return channelStopTypingEventHandler(v)
+	case func(*RevoltBot, ChannelAck):
+		return channelAckEventHandler(v)
+	case func(*RevoltBot, ServerUpdate):
+		return serverUpdateEventHandler(v)
+	case func(*RevoltBot, ServerDelete):
+		return serverDeleteEventHandler(v)
+	case func(*RevoltBot, ServerMemberUpdate):
+		return serverMemberUpdateEventHandler(v)
+	case func(*RevoltBot, ServerMemberJoin):
+		return serverMemberJoinEventHandler(v)
+	case func(*RevoltBot, ServerMemberLeave):
+		return serverMemberLeaveEventHandler(v)
+	case func(*RevoltBot, ServerRoleUpdate):
+		return serverRoleUpdateEventHandler(v)
+	case func(*RevoltBot, ServerRoleDelete):
+		return serverRoleDeleteEventHandler(v)
+	case func(*RevoltBot, UserUpdate):
+		return userUpdateEventHandler(v)
+	case func(*RevoltBot, UserRelationship):
+		return userRelationshipEventHandler(v)
+	}
+
+	return nil
+}
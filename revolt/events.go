@@ -1,5 +1,7 @@
 package revolt
 
+//go:generate go run eventgen.go
+
 type SentBase struct {
 	Type string `json:"type"`
 }
@@ -28,7 +30,7 @@ type EndTyping struct {
 type Ping struct {
 	SentBase
 
-	Time int `json:"time"`
+	Time int64 `json:"time"`
 }
 
 type Error struct {
@@ -61,18 +63,27 @@ type MessageDelete struct {
 
 	MessageID string `json:"id"`
 	ChannelID string `json:"channel"`
+
+	// The deleted message, if it was cached.
+	Message *Message `json:"-"`
 }
 
 type Pong struct {
 	SentBase
 
-	Time int `json:"time"`
+	Time int64 `json:"time"`
 }
 type MessageUpdate struct {
 	SentBase
 
-	ID      string   `json:"id"`
+	ID string `json:"id"`
+
+	// The updated message. Only the changed fields are set unless the
+	// message was cached.
 	Message *Message `json:"data"`
+
+	// The message before it was updated, if it was cached.
+	Before *Message `json:"-"`
 }
 
 type ChannelCreate struct {
@@ -83,15 +94,25 @@ type ChannelCreate struct {
 
 type ChannelUpdate struct {
 	SentBase
-	ID      string   `json:"id"`
+
+	ID string `json:"id"`
+
+	// The updated channel. Partial updates do not include the type of the
+	// channel, so its fields are only set if the channel was cached.
 	Channel *Channel `json:"data"`
 	Clear   string   `json:"clear"`
+
+	// The channel before it was updated, if it was cached.
+	Before *Channel `json:"-"`
 }
 
 type ChannelDelete struct {
 	SentBase
 
 	ID string `json:"id"`
+
+	// The deleted channel, if it was cached.
+	Channel *Channel `json:"-"`
 }
 
 type ChannelGroupJoin struct {
@@ -133,22 +154,39 @@ type ChannelAck struct {
 type ServerUpdate struct {
 	SentBase
 
-	*Guild
+	GuildID string `json:"id"`
+
+	// The updated server. Only the changed fields are set unless the
+	// server was cached.
+	Guild *Guild `json:"data"`
 	Clear string `json:"clear"`
+
+	// The server before it was updated, if it was cached.
+	Before *Guild `json:"-"`
 }
 
 type ServerDelete struct {
 	SentBase
 
 	GuildID string `json:"id"`
+
+	// The deleted server, if it was cached. Its channels and members are
+	// removed from the cache too.
+	Guild *Guild `json:"-"`
 }
 
 type ServerMemberUpdate struct {
 	SentBase
 
-	GuildID string       `json:"id"`
-	Member  *GuildMember `json:"data"`
-	Clear   string       `json:"clear"`
+	ID *GuildMemberIDs `json:"id"`
+
+	// The updated member. Only the changed fields are set unless the
+	// member was cached.
+	Member *GuildMember `json:"data"`
+	Clear  string       `json:"clear"`
+
+	// The member before it was updated, if it was cached.
+	Before *GuildMember `json:"-"`
 }
 
 type ServerMemberJoin struct {
@@ -156,6 +194,9 @@ type ServerMemberJoin struct {
 
 	GuildID string `json:"id"`
 	UserID  string `json:"user"`
+
+	// The member that was added to the cache.
+	Member *GuildMember `json:"-"`
 }
 
 type ServerMemberLeave struct {
@@ -163,13 +204,24 @@ type ServerMemberLeave struct {
 
 	GuildID string `json:"id"`
 	UserID  string `json:"user"`
+
+	// The member that left, if it was cached.
+	Member *GuildMember `json:"-"`
 }
 
 type ServerRoleUpdate struct {
 	SentBase
 
 	GuildID string `json:"id"`
-	UserID  string `json:"user"`
+	RoleID  string `json:"role_id"`
+
+	// The updated role. Only the changed fields are set unless the server
+	// was cached.
+	Role  *GuildRole `json:"data"`
+	Clear string     `json:"clear"`
+
+	// The role before it was updated, if it was cached.
+	Before *GuildRole `json:"-"`
 }
 
 type ServerRoleDelete struct {
@@ -177,14 +229,23 @@ type ServerRoleDelete struct {
 
 	GuildID string `json:"id"`
 	RoleID  string `json:"role_id"`
+
+	// The deleted role, if the server was cached.
+	Role *GuildRole `json:"-"`
 }
 
 type UserUpdate struct {
 	SentBase
 
 	UserID string `json:"id"`
-	Data   *User  `json:"data"`
-	Clear  string `json:"clear"`
+
+	// The updated user. Only the changed fields are set unless the user
+	// was cached.
+	Data  *User  `json:"data"`
+	Clear string `json:"clear"`
+
+	// The user before it was updated, if it was cached.
+	Before *User `json:"-"`
 }
 
 type UserRelationship struct {
@@ -0,0 +1,233 @@
+package revolt
+
+import (
+	"crypto/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GuildEdit changes the properties of a server. Nil fields are left
+// unchanged.
+type GuildEdit struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// Autumn IDs of the uploaded icon and banner.
+	Icon   *string `json:"icon,omitempty"`
+	Banner *string `json:"banner,omitempty"`
+
+	Categories     []*GuildCategory     `json:"categories,omitempty"`
+	SystemMessages *GuildSystemMessages `json:"system_messages,omitempty"`
+
+	// Field to remove from the server: Icon, Banner or Description.
+	Remove string `json:"remove,omitempty"`
+}
+
+// GuildRoleEdit changes the properties of a role. Nil fields are left
+// unchanged.
+type GuildRoleEdit struct {
+	Name   *string `json:"name,omitempty"`
+	Colour *string `json:"colour,omitempty"`
+	Hoist  *bool   `json:"hoist,omitempty"`
+	Rank   *int    `json:"rank,omitempty"`
+
+	// Field to remove from the role: Colour.
+	Remove string `json:"remove,omitempty"`
+}
+
+// FetchGuild fetches a server and adds it to the cache.
+func (rb *RevoltBot) FetchGuild(guildID string) (guild *Guild, err error) {
+	err = rb.request(http.MethodGet, "/servers/"+guildID, nil, &guild)
+	if err != nil {
+		return nil, err
+	}
+
+	rb.State.SetGuild(guild)
+
+	return guild, nil
+}
+
+// EditGuild edits a server. The cache is updated by the ServerUpdate event
+// that follows.
+func (rb *RevoltBot) EditGuild(guildID string, guildEdit *GuildEdit) (err error) {
+	return rb.request(http.MethodPatch, "/servers/"+guildID, guildEdit, nil)
+}
+
+// guild returns the cached server, fetching it if it is missing.
+func (rb *RevoltBot) guild(guildID string) (guild *Guild, err error) {
+	if guild, ok := rb.Guild(guildID); ok {
+		return guild, nil
+	}
+
+	return rb.FetchGuild(guildID)
+}
+
+// CreateCategory adds a category to the end of a server's categories and
+// returns it. Categories are stored on the server, so this replaces every
+// category with the ones currently known.
+func (rb *RevoltBot) CreateCategory(guildID string, title string, channelIDs []string) (category *GuildCategory, err error) {
+	guild, err := rb.guild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	category = &GuildCategory{
+		ID:       newID(),
+		Title:    title,
+		Channels: channelIDs,
+	}
+
+	categories := append(append([]*GuildCategory(nil), guild.Categories...), category)
+
+	err = rb.EditGuild(guildID, &GuildEdit{Categories: categories})
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// EditCategory replaces the category with the same ID as category.
+func (rb *RevoltBot) EditCategory(guildID string, category *GuildCategory) (err error) {
+	guild, err := rb.guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	categories := make([]*GuildCategory, 0, len(guild.Categories))
+	found := false
+
+	for _, c := range guild.Categories {
+		if c.ID == category.ID {
+			c = category
+			found = true
+		}
+
+		categories = append(categories, c)
+	}
+
+	if !found {
+		return ErrNotCached
+	}
+
+	return rb.EditGuild(guildID, &GuildEdit{Categories: categories})
+}
+
+// DeleteCategory removes a category. Its channels are not deleted.
+func (rb *RevoltBot) DeleteCategory(guildID string, categoryID string) (err error) {
+	guild, err := rb.guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	categories := make([]*GuildCategory, 0, len(guild.Categories))
+
+	for _, c := range guild.Categories {
+		if c.ID != categoryID {
+			categories = append(categories, c)
+		}
+	}
+
+	if len(categories) == len(guild.Categories) {
+		return ErrNotCached
+	}
+
+	// An empty list would be left out of the request.
+	return rb.request(http.MethodPatch, "/servers/"+guildID, struct {
+		Categories []*GuildCategory `json:"categories"`
+	}{categories}, nil)
+}
+
+// CreateRole creates a role with the default permissions and returns its ID.
+func (rb *RevoltBot) CreateRole(guildID string, name string) (roleID string, role *GuildRole, err error) {
+	var res struct {
+		ID          string        `json:"id"`
+		Permissions PermissionSet `json:"permissions"`
+	}
+
+	err = rb.request(http.MethodPost, "/servers/"+guildID+"/roles", struct {
+		Name string `json:"name"`
+	}{name}, &res)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return res.ID, &GuildRole{
+		Name:        name,
+		Permissions: res.Permissions,
+	}, nil
+}
+
+func (rb *RevoltBot) EditRole(guildID string, roleID string, roleEdit *GuildRoleEdit) (err error) {
+	return rb.request(http.MethodPatch, "/servers/"+guildID+"/roles/"+roleID, roleEdit, nil)
+}
+
+func (rb *RevoltBot) DeleteRole(guildID string, roleID string) (err error) {
+	return rb.request(http.MethodDelete, "/servers/"+guildID+"/roles/"+roleID, nil, nil)
+}
+
+// ReorderRoles sets the rank of each role to its index in roleIDs, so the
+// first role takes priority over the others. Roles that already have the
+// right rank are not edited.
+func (rb *RevoltBot) ReorderRoles(guildID string, roleIDs []string) (err error) {
+	guild, err := rb.guild(guildID)
+	if err != nil {
+		return err
+	}
+
+	for rank, roleID := range roleIDs {
+		if role, ok := guild.Roles[roleID]; ok && role.Rank == rank {
+			continue
+		}
+
+		rank := rank
+
+		err = rb.EditRole(guildID, roleID, &GuildRoleEdit{Rank: &rank})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetRolePermissions sets the permissions a role grants. Use "default" as
+// the role ID to set the permissions everyone in the server has.
+func (rb *RevoltBot) SetRolePermissions(guildID string, roleID string, serverPerms ServerPermission, channelPerms ChannelPermission) (err error) {
+	type permissions struct {
+		Server  ServerPermission  `json:"server"`
+		Channel ChannelPermission `json:"channel"`
+	}
+
+	return rb.request(http.MethodPut, "/servers/"+guildID+"/permissions/"+roleID, struct {
+		Permissions permissions `json:"permissions"`
+	}{permissions{serverPerms, channelPerms}}, nil)
+}
+
+// SetDefaultPermissions sets the permissions everyone in the server has.
+func (rb *RevoltBot) SetDefaultPermissions(guildID string, serverPerms ServerPermission, channelPerms ChannelPermission) (err error) {
+	return rb.SetRolePermissions(guildID, "default", serverPerms, channelPerms)
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newID returns a new ULID, the format of the IDs used by Revolt, for
+// objects such as categories whose ID is chosen by the client.
+func newID() string {
+	var b strings.Builder
+
+	ms := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	for i := 9; i >= 0; i-- {
+		b.WriteByte(crockford[(ms>>(uint(i)*5))&31])
+	}
+
+	random := make([]byte, 16)
+	rand.Read(random)
+
+	for _, r := range random {
+		b.WriteByte(crockford[r&31])
+	}
+
+	return b.String()
+}
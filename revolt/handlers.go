@@ -0,0 +1,106 @@
+package revolt
+
+import "sync"
+
+// interfaceEventType is the key used for handlers that receive every event.
+const interfaceEventType = "__INTERFACE__"
+
+// EventHandler is implemented by the typed wrappers around the functions
+// passed to AddHandler.
+type EventHandler interface {
+	// Type returns the gateway event type the handler is for.
+	Type() string
+
+	// Handle calls the handler if the event is of the expected type.
+	Handle(*RevoltBot, interface{})
+}
+
+type eventHandlerInstance struct {
+	eventHandler EventHandler
+	once         bool
+}
+
+type handlerRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string][]*eventHandlerInstance
+}
+
+// AddHandler registers a function to be called for every event of the type
+// it accepts, such as func(*RevoltBot, MessageCreate). A handler of type
+// func(*RevoltBot, interface{}) receives every event. The returned function
+// removes the handler again.
+func (rb *RevoltBot) AddHandler(handler interface{}) func() {
+	return rb.addHandler(handler, false)
+}
+
+// AddHandlerOnce is the same as AddHandler, but the handler is removed after
+// it has been called once.
+func (rb *RevoltBot) AddHandlerOnce(handler interface{}) func() {
+	return rb.addHandler(handler, true)
+}
+
+func (rb *RevoltBot) addHandler(handler interface{}, once bool) func() {
+	eh := handlerForInterface(handler)
+	if eh == nil {
+		println("Invalid handler type, handler will never be called")
+
+		return func() {}
+	}
+
+	ehi := &eventHandlerInstance{eh, once}
+
+	rb.handlers.mu.Lock()
+	if rb.handlers.handlers == nil {
+		rb.handlers.handlers = make(map[string][]*eventHandlerInstance)
+	}
+	rb.handlers.handlers[eh.Type()] = append(rb.handlers.handlers[eh.Type()], ehi)
+	rb.handlers.mu.Unlock()
+
+	return func() {
+		rb.removeHandler(eh.Type(), ehi)
+	}
+}
+
+func (rb *RevoltBot) removeHandler(t string, ehi *eventHandlerInstance) (removed bool) {
+	rb.handlers.mu.Lock()
+	defer rb.handlers.mu.Unlock()
+
+	handlers := rb.handlers.handlers[t]
+	for i, h := range handlers {
+		if h == ehi {
+			rb.handlers.handlers[t] = append(handlers[:i:i], handlers[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// handle calls every handler registered for the event type, and the
+// interface handlers.
+func (rb *RevoltBot) handle(t string, event interface{}) {
+	rb.handlers.mu.RLock()
+	handlers := append(rb.handlers.handlers[t][:0:0], rb.handlers.handlers[t]...)
+	handlers = append(handlers, rb.handlers.handlers[interfaceEventType]...)
+	rb.handlers.mu.RUnlock()
+
+	for _, ehi := range handlers {
+		// Once handlers only run for whoever manages to remove them first.
+		if ehi.once && !rb.removeHandler(ehi.eventHandler.Type(), ehi) {
+			continue
+		}
+
+		ehi.eventHandler.Handle(rb, event)
+	}
+}
+
+type interfaceEventHandler func(*RevoltBot, interface{})
+
+func (eh interfaceEventHandler) Type() string {
+	return interfaceEventType
+}
+
+func (eh interfaceEventHandler) Handle(rb *RevoltBot, i interface{}) {
+	eh(rb, i)
+}
@@ -0,0 +1,109 @@
+package revolt
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+// Number of round-trips used when calculating AverageLatency.
+const latencySamples = 10
+
+// Heartbeat sends a Ping every HeartbeatInterval until ctx is done. If more
+// than MaxMissedPongs pings are left unanswered, conn is closed so that Start
+// reconnects.
+func (rb *RevoltBot) Heartbeat(ctx context.Context, conn *websocket.Conn) {
+	t := time.NewTicker(rb.HeartbeatInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			rb.heartbeatMu.Lock()
+			missed := len(rb.pendingPings)
+			rb.heartbeatMu.Unlock()
+
+			if rb.MaxMissedPongs > 0 && missed >= rb.MaxMissedPongs {
+				println("Missed " + strconv.Itoa(missed) + " pongs, reconnecting")
+				conn.Close(websocket.StatusGoingAway, "heartbeat timeout")
+
+				return
+			}
+
+			now := time.Now()
+			ping := Ping{
+				SentBase: SentBase{EventTypePing},
+				Time:     now.UnixNano() / int64(time.Millisecond),
+			}
+
+			rb.heartbeatMu.Lock()
+			rb.pendingPings[ping.Time] = now
+			rb.heartbeatMu.Unlock()
+
+			rb.SendEvent(ping)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// onPong matches a Pong to the Ping it answers and records the round-trip.
+func (rb *RevoltBot) onPong(o Pong) {
+	rb.heartbeatMu.Lock()
+	defer rb.heartbeatMu.Unlock()
+
+	sent, ok := rb.pendingPings[o.Time]
+	if !ok {
+		return
+	}
+
+	// Any ping sent before this one will not be answered anymore.
+	for t, s := range rb.pendingPings {
+		if !s.After(sent) {
+			delete(rb.pendingPings, t)
+		}
+	}
+
+	rb.latencies = append(rb.latencies, time.Since(sent))
+	if len(rb.latencies) > latencySamples {
+		rb.latencies = rb.latencies[len(rb.latencies)-latencySamples:]
+	}
+}
+
+// Latency returns the round-trip time of the last answered Ping.
+func (rb *RevoltBot) Latency() time.Duration {
+	rb.heartbeatMu.Lock()
+	defer rb.heartbeatMu.Unlock()
+
+	if len(rb.latencies) == 0 {
+		return 0
+	}
+
+	return rb.latencies[len(rb.latencies)-1]
+}
+
+// AverageLatency returns the mean round-trip time of the recent pings.
+func (rb *RevoltBot) AverageLatency() time.Duration {
+	rb.heartbeatMu.Lock()
+	defer rb.heartbeatMu.Unlock()
+
+	if len(rb.latencies) == 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, l := range rb.latencies {
+		total += l
+	}
+
+	return total / time.Duration(len(rb.latencies))
+}
+
+func (rb *RevoltBot) resetHeartbeat() {
+	rb.heartbeatMu.Lock()
+	rb.pendingPings = make(map[int64]time.Time)
+	rb.latencies = nil
+	rb.heartbeatMu.Unlock()
+}
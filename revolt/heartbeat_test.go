@@ -0,0 +1,77 @@
+package revolt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+func TestHeartbeatTimeout(t *testing.T) {
+	pinged := make(chan Ping, 1)
+
+	rb, tokens := newFakeGateway(t, func(ctx context.Context, conn *websocket.Conn, n int) {
+		for {
+			_, buf, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+
+			o := Ping{}
+			if json.Unmarshal(buf, &o) != nil || o.Type != EventTypePing {
+				continue
+			}
+
+			// The first connection stops answering pings.
+			if n == 1 {
+				continue
+			}
+
+			pong, _ := json.Marshal(Pong{SentBase: SentBase{EventTypePong}, Time: o.Time})
+			if conn.Write(ctx, websocket.MessageText, pong) != nil {
+				return
+			}
+
+			select {
+			case pinged <- o:
+			default:
+			}
+		}
+	})
+
+	rb.HeartbeatInterval = 10 * time.Millisecond
+	rb.MaxMissedPongs = 2
+
+	stop := startBot(t, rb)
+
+	var ping Ping
+
+	select {
+	case ping = <-pinged:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no ping answered after %d connections", len(tokens()))
+	}
+
+	if n := len(tokens()); n < 2 {
+		t.Errorf("bot connected %d times, want a reconnect after missing pongs", n)
+	}
+
+	// Milliseconds since the epoch do not fit in 32 bits.
+	if now := time.Now().UnixNano() / int64(time.Millisecond); ping.Time < now-time.Minute.Milliseconds() || ping.Time > now {
+		t.Errorf("ping time %d is not the current time in milliseconds", ping.Time)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for rb.Latency() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no latency recorded from the answered pings")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := stop(); err != nil {
+		t.Errorf("Start() = %v, want nil after Shutdown", err)
+	}
+}
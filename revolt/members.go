@@ -0,0 +1,221 @@
+package revolt
+
+import "net/http"
+
+type GuildMembers struct {
+	Members []*GuildMember `json:"members"`
+	Users   []*User        `json:"users"`
+}
+
+// FetchMembers fetches every member of a server along with their users and
+// adds them to the cache.
+func (rb *RevoltBot) FetchMembers(guildID string) (members *GuildMembers, err error) {
+	err = rb.request(http.MethodGet, "/servers/"+guildID+"/members", nil, &members)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range members.Members {
+		if m.ID != nil {
+			rb.State.SetMember(m)
+		}
+	}
+
+	for _, u := range members.Users {
+		rb.State.SetUser(u)
+	}
+
+	return members, nil
+}
+
+// FetchMember fetches a single member of a server and adds it to the cache.
+func (rb *RevoltBot) FetchMember(guildID string, userID string) (member *GuildMember, err error) {
+	err = rb.request(http.MethodGet, "/servers/"+guildID+"/members/"+userID, nil, &member)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.ID != nil {
+		rb.State.SetMember(member)
+	}
+
+	return member, nil
+}
+
+// GuildMemberEdit changes a member of a server. Nil fields are left
+// unchanged.
+type GuildMemberEdit struct {
+	Nickname *string `json:"nickname,omitempty"`
+
+	// Autumn ID of the uploaded avatar.
+	Avatar *string `json:"avatar,omitempty"`
+
+	// Replaces every role of the member. Use AddMemberRole and
+	// RemoveMemberRole to change a single role.
+	Roles []string `json:"roles,omitempty"`
+
+	// Field to remove from the member: Nickname or Avatar.
+	Remove string `json:"remove,omitempty"`
+}
+
+type GuildBan struct {
+	ID     *GuildMemberIDs `json:"_id"`
+	Reason string          `json:"reason"`
+}
+
+// GuildBans lists the bans of a server along with the users that were
+// banned. The users only have their ID, username and avatar set.
+type GuildBans struct {
+	Users []*User     `json:"users"`
+	Bans  []*GuildBan `json:"bans"`
+}
+
+// EditMember edits a member of a server. The nickname, roles and removed
+// field are applied to the cache straight away, the avatar once the
+// ServerMemberUpdate event arrives.
+func (rb *RevoltBot) EditMember(guildID string, userID string, memberEdit *GuildMemberEdit) (err error) {
+	err = rb.request(http.MethodPatch, "/servers/"+guildID+"/members/"+userID, memberEdit, nil)
+	if err != nil {
+		return err
+	}
+
+	rb.updateMember(guildID, userID, func(m *GuildMember) {
+		if memberEdit.Nickname != nil {
+			m.Nickname = *memberEdit.Nickname
+		}
+
+		if memberEdit.Roles != nil {
+			m.Roles = append([]string(nil), memberEdit.Roles...)
+		}
+
+		m.clear(memberEdit.Remove)
+	})
+
+	return nil
+}
+
+// AddMemberRole gives a role to a member. The API only accepts the full list
+// of roles, so the member is fetched first to start from their current
+// roles rather than the cached ones. Calls to AddMemberRole and
+// RemoveMemberRole are serialised, but a change made elsewhere between the
+// fetch and the edit is still overwritten.
+func (rb *RevoltBot) AddMemberRole(guildID string, userID string, roleID string) (err error) {
+	return rb.editMemberRoles(guildID, userID, func(roles []string) []string {
+		for _, r := range roles {
+			if r == roleID {
+				return nil
+			}
+		}
+
+		return append(roles, roleID)
+	})
+}
+
+// RemoveMemberRole takes a role away from a member.
+func (rb *RevoltBot) RemoveMemberRole(guildID string, userID string, roleID string) (err error) {
+	return rb.editMemberRoles(guildID, userID, func(roles []string) []string {
+		for _, r := range roles {
+			if r == roleID {
+				return removeString(roles, roleID)
+			}
+		}
+
+		return nil
+	})
+}
+
+// editMemberRoles replaces the roles of a member with the ones returned by
+// edit, which is passed a copy of the roles the member has according to the
+// API. A nil result means there is nothing to change.
+func (rb *RevoltBot) editMemberRoles(guildID string, userID string, edit func(roles []string) []string) (err error) {
+	rb.memberRolesMu.Lock()
+	defer rb.memberRolesMu.Unlock()
+
+	member, err := rb.FetchMember(guildID, userID)
+	if err != nil {
+		return err
+	}
+
+	roles := edit(append([]string{}, member.Roles...))
+	if roles == nil {
+		return nil
+	}
+
+	// Roles is sent even when empty to remove the last role.
+	err = rb.request(http.MethodPatch, "/servers/"+guildID+"/members/"+userID, struct {
+		Roles []string `json:"roles"`
+	}{roles}, nil)
+	if err != nil {
+		return err
+	}
+
+	rb.updateMember(guildID, userID, func(m *GuildMember) {
+		m.Roles = roles
+	})
+
+	return nil
+}
+
+// updateMember applies update to a copy of the cached member, if any, and
+// stores it.
+func (rb *RevoltBot) updateMember(guildID string, userID string, update func(m *GuildMember)) {
+	rb.stateMu.Lock()
+	defer rb.stateMu.Unlock()
+
+	member, ok := rb.State.GetMember(guildID, userID)
+	if !ok {
+		return
+	}
+
+	member = member.copy()
+	update(member)
+
+	rb.State.SetMember(member)
+}
+
+// KickMember removes a member from a server and from the cache.
+func (rb *RevoltBot) KickMember(guildID string, userID string) (err error) {
+	err = rb.request(http.MethodDelete, "/servers/"+guildID+"/members/"+userID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	rb.removeMember(guildID, userID)
+
+	return nil
+}
+
+// BanMember bans a user from a server, removing them from it if they are a
+// member. The reason may be empty.
+func (rb *RevoltBot) BanMember(guildID string, userID string, reason string) (err error) {
+	err = rb.request(http.MethodPut, "/servers/"+guildID+"/bans/"+userID, struct {
+		Reason string `json:"reason,omitempty"`
+	}{reason}, nil)
+	if err != nil {
+		return err
+	}
+
+	rb.removeMember(guildID, userID)
+
+	return nil
+}
+
+func (rb *RevoltBot) UnbanMember(guildID string, userID string) (err error) {
+	return rb.request(http.MethodDelete, "/servers/"+guildID+"/bans/"+userID, nil, nil)
+}
+
+func (rb *RevoltBot) FetchBans(guildID string) (bans *GuildBans, err error) {
+	err = rb.request(http.MethodGet, "/servers/"+guildID+"/bans", nil, &bans)
+	if err != nil {
+		return nil, err
+	}
+
+	return bans, nil
+}
+
+func (rb *RevoltBot) removeMember(guildID string, userID string) {
+	rb.stateMu.Lock()
+	defer rb.stateMu.Unlock()
+
+	rb.State.DeleteMember(guildID, userID)
+}
@@ -0,0 +1,102 @@
+package revolt
+
+import (
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestEditMemberRoles(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(rb *RevoltBot) error
+		want []string
+	}{
+		{
+			name: "add",
+			edit: func(rb *RevoltBot) error {
+				return rb.AddMemberRole("guild", "user", "new")
+			},
+			want: []string{"cached", "fetched", "new"},
+		},
+		{
+			name: "add existing",
+			edit: func(rb *RevoltBot) error {
+				return rb.AddMemberRole("guild", "user", "fetched")
+			},
+		},
+		{
+			name: "remove",
+			edit: func(rb *RevoltBot) error {
+				return rb.RemoveMemberRole("guild", "user", "cached")
+			},
+			want: []string{"fetched"},
+		},
+		{
+			name: "remove missing",
+			edit: func(rb *RevoltBot) error {
+				return rb.RemoveMemberRole("guild", "user", "new")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var mu sync.Mutex
+
+			var patched []string
+
+			rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/servers/guild/members/user" {
+					t.Errorf("request to %s", r.URL.Path)
+				}
+
+				switch r.Method {
+				case http.MethodGet:
+					// The member was given a role since it was cached.
+					w.Write([]byte(`{"_id": {"server": "guild", "user": "user"}, "roles": ["cached", "fetched"]}`))
+				case http.MethodPatch:
+					body, _ := ioutil.ReadAll(r.Body)
+
+					var edit struct {
+						Roles []string `json:"roles"`
+					}
+
+					if err := json.Unmarshal(body, &edit); err != nil {
+						t.Error(err)
+					}
+
+					mu.Lock()
+					patched = edit.Roles
+					mu.Unlock()
+
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+
+			rb.State.SetMember(&GuildMember{ID: &GuildMemberIDs{Server: "guild", User: "user"}, Roles: []string{"cached"}})
+
+			if err := test.edit(rb); err != nil {
+				t.Fatal(err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if !reflect.DeepEqual(patched, test.want) {
+				t.Errorf("sent roles %v, want %v", patched, test.want)
+			}
+
+			want := test.want
+			if want == nil {
+				want = []string{"cached", "fetched"}
+			}
+
+			if m, _ := rb.State.GetMember("guild", "user"); !reflect.DeepEqual(m.Roles, want) {
+				t.Errorf("cached roles = %v, want %v", m.Roles, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package revolt
+
+import (
+	"errors"
+	"regexp"
+)
+
+// IDs are ULIDs, written in Crockford's base32.
+const idPattern = `([0-9A-HJKMNP-TV-Z]{26})`
+
+var (
+	userMentionRegex    = regexp.MustCompile(`<@` + idPattern + `>`)
+	channelMentionRegex = regexp.MustCompile(`<#` + idPattern + `>`)
+	roleMentionRegex    = regexp.MustCompile(`<%` + idPattern + `>`)
+	emojiRegex          = regexp.MustCompile(`:` + idPattern + `:`)
+)
+
+// findIDs returns the IDs matched by re in content, once each and in the
+// order they first appear.
+func findIDs(re *regexp.Regexp, content string) (ids []string) {
+	seen := make(map[string]bool)
+
+	for _, match := range re.FindAllStringSubmatch(content, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			ids = append(ids, match[1])
+		}
+	}
+
+	return ids
+}
+
+// MentionedUserIDs returns the IDs of the users mentioned with <@id> in the
+// content of the message.
+func (m *Message) MentionedUserIDs() []string {
+	return findIDs(userMentionRegex, m.Content)
+}
+
+// MentionedChannelIDs returns the IDs of the channels linked with <#id> in
+// the content of the message.
+func (m *Message) MentionedChannelIDs() []string {
+	return findIDs(channelMentionRegex, m.Content)
+}
+
+// MentionedRoleIDs returns the IDs of the roles mentioned with <%id> in the
+// content of the message.
+func (m *Message) MentionedRoleIDs() []string {
+	return findIDs(roleMentionRegex, m.Content)
+}
+
+// EmojiIDs returns the IDs of the custom emoji used with :id: in the
+// content of the message.
+func (m *Message) EmojiIDs() []string {
+	return findIDs(emojiRegex, m.Content)
+}
+
+// isNotFound reports if err is the API saying an object does not exist, in
+// which case a mention of it is skipped.
+func isNotFound(err error) bool {
+	return errors.Is(err, ErrAPINotFound)
+}
+
+// MentionedUsers returns the users mentioned in a message, fetching the
+// ones that are not cached. Users that do not exist are left out.
+func (rb *RevoltBot) MentionedUsers(message *Message) (users []*User, err error) {
+	for _, id := range message.MentionedUserIDs() {
+		user, ok := rb.User(id)
+		if !ok {
+			user, err = rb.FetchUser(id)
+			if isNotFound(err) {
+				continue
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// MentionedChannels returns the channels linked in a message, fetching the
+// ones that are not cached. Channels that do not exist or cannot be seen
+// are left out.
+func (rb *RevoltBot) MentionedChannels(message *Message) (channels []*Channel, err error) {
+	for _, id := range message.MentionedChannelIDs() {
+		channel, ok := rb.Channel(id)
+		if !ok {
+			channel, err = rb.FetchChannel(id)
+			if isNotFound(err) || errors.Is(err, ErrAPIMissingPermission) {
+				continue
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		channels = append(channels, channel)
+	}
+
+	return channels, nil
+}
+
+// MentionedRoles returns the roles mentioned in a message, keyed by their
+// ID. Roles are looked up in the server the message was sent in, which is
+// fetched if it is not cached. Roles of other servers are left out.
+func (rb *RevoltBot) MentionedRoles(message *Message) (roles map[string]*GuildRole, err error) {
+	roleIDs := message.MentionedRoleIDs()
+	if len(roleIDs) == 0 {
+		return nil, nil
+	}
+
+	guild, err := rb.messageGuild(message)
+	if guild == nil || err != nil {
+		return nil, err
+	}
+
+	roles = make(map[string]*GuildRole, len(roleIDs))
+
+	for _, id := range roleIDs {
+		if role, ok := guild.Roles[id]; ok {
+			roles[id] = role
+		}
+	}
+
+	return roles, nil
+}
+
+// messageGuild returns the server a message was sent in, or nil if it was
+// not sent in a server.
+func (rb *RevoltBot) messageGuild(message *Message) (guild *Guild, err error) {
+	channel, ok := rb.Channel(message.ChannelID)
+	if !ok {
+		channel, err = rb.FetchChannel(message.ChannelID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if channel.GuildID() == "" {
+		return nil, nil
+	}
+
+	return rb.guild(channel.GuildID())
+}
+
+// DisplayContent returns the content of a message with user and role
+// mentions replaced by @name and channel links by #name. Users are shown by
+// their nickname if they are a cached member of the server. Mentions that
+// cannot be resolved are left as is.
+func (rb *RevoltBot) DisplayContent(message *Message) (content string, err error) {
+	users, err := rb.MentionedUsers(message)
+	if err != nil {
+		return "", err
+	}
+
+	channels, err := rb.MentionedChannels(message)
+	if err != nil {
+		return "", err
+	}
+
+	roles, err := rb.MentionedRoles(message)
+	if err != nil {
+		return "", err
+	}
+
+	var guildID string
+	if channel, ok := rb.Channel(message.ChannelID); ok {
+		guildID = channel.GuildID()
+	}
+
+	// Names to show keyed by the mention they replace.
+	names := make(map[string]string, len(users)+len(channels)+len(roles))
+
+	for _, user := range users {
+		names[MentionUser(user.ID)] = "@" + user.Username
+
+		if member, ok := rb.Member(guildID, user.ID); ok && member.Nickname != "" {
+			names[MentionUser(user.ID)] = "@" + member.Nickname
+		}
+	}
+
+	for _, channel := range channels {
+		if name := channel.Name(); name != "" {
+			names[MentionChannel(channel.ID)] = "#" + name
+		}
+	}
+
+	for id, role := range roles {
+		names[MentionRole(id)] = "@" + role.Name
+	}
+
+	replace := func(mention string) string {
+		if name, ok := names[mention]; ok {
+			return name
+		}
+
+		return mention
+	}
+
+	content = userMentionRegex.ReplaceAllStringFunc(message.Content, replace)
+	content = channelMentionRegex.ReplaceAllStringFunc(content, replace)
+	content = roleMentionRegex.ReplaceAllStringFunc(content, replace)
+
+	return content, nil
+}
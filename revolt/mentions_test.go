@@ -0,0 +1,123 @@
+package revolt
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const (
+	idUserA   = "01G8ZY4AAAAAAAAAAAAAAAAAAA"
+	idUserB   = "01G8ZY4BBBBBBBBBBBBBBBBBBB"
+	idUnknown = "01G8ZY4CCCCCCCCCCCCCCCCCCC"
+	idChannel = "01G8ZY4DDDDDDDDDDDDDDDDDDD"
+	idHidden  = "01G8ZY4EEEEEEEEEEEEEEEEEEE"
+	idRole    = "01G8ZY4FFFFFFFFFFFFFFFFFFF"
+)
+
+func TestMentionedIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     func(m *Message) []string
+		content string
+		want    []string
+	}{
+		{
+			name:    "users",
+			ids:     (*Message).MentionedUserIDs,
+			content: "<@" + idUserA + "> and <@" + idUserB + ">",
+			want:    []string{idUserA, idUserB},
+		},
+		{
+			name:    "users once each",
+			ids:     (*Message).MentionedUserIDs,
+			content: "<@" + idUserB + "> <@" + idUserA + "> <@" + idUserB + ">",
+			want:    []string{idUserB, idUserA},
+		},
+		{
+			name:    "users of other kinds of mentions",
+			ids:     (*Message).MentionedUserIDs,
+			content: "<#" + idUserA + "> <%" + idUserA + "> :" + idUserA + ":",
+		},
+		{
+			name:    "lowercase",
+			ids:     (*Message).MentionedUserIDs,
+			content: "<@01g8zy4aaaaaaaaaaaaaaaaaaa>",
+		},
+		{
+			name:    "too short",
+			ids:     (*Message).MentionedUserIDs,
+			content: "<@" + idUserA[1:] + ">",
+		},
+		{
+			name:    "not base32",
+			ids:     (*Message).MentionedUserIDs,
+			content: "<@01G8ZY4UUUUUUUUUUUUUUUUUUU>",
+		},
+		{
+			name:    "channels",
+			ids:     (*Message).MentionedChannelIDs,
+			content: "see <#" + idChannel + "> and <#" + idChannel + ">",
+			want:    []string{idChannel},
+		},
+		{
+			name:    "roles",
+			ids:     (*Message).MentionedRoleIDs,
+			content: "<%" + idRole + ">, <@" + idUserA + ">",
+			want:    []string{idRole},
+		},
+		{
+			name:    "emoji",
+			ids:     (*Message).EmojiIDs,
+			content: ":" + idUserA + "::" + idUserB + ": :" + idUserA + ":",
+			want:    []string{idUserA, idUserB},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.ids(&Message{Content: test.content}); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("IDs in %q = %v, want %v", test.content, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDisplayContent(t *testing.T) {
+	rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/" + idUnknown:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type": "NotFound"}`))
+		case "/channels/" + idHidden:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"type": "MissingPermission"}`))
+		default:
+			t.Errorf("request to %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	rb.State.SetGuild(&Guild{ID: "guild", Roles: map[string]*GuildRole{idRole: {Name: "mods"}}})
+	rb.State.SetChannel(&Channel{ID: "here", ChannelType: ChannelTypeText, TextChannel: &TextChannel{Server: "guild", Name: "here"}})
+	rb.State.SetChannel(&Channel{ID: idChannel, ChannelType: ChannelTypeText, TextChannel: &TextChannel{Server: "guild", Name: "general"}})
+	rb.State.SetUser(&User{ID: idUserA, Username: "alice"})
+	rb.State.SetUser(&User{ID: idUserB, Username: "bob"})
+	rb.State.SetMember(&GuildMember{ID: &GuildMemberIDs{Server: "guild", User: idUserA}, Nickname: "ally"})
+	rb.State.SetMember(&GuildMember{ID: &GuildMemberIDs{Server: "guild", User: idUserB}})
+
+	message := &Message{
+		ChannelID: "here",
+		Content:   "<@" + idUserA + "> <@" + idUserB + "> <@" + idUnknown + "> <#" + idChannel + "> <#" + idHidden + "> <%" + idRole + "> <@" + idUserA + ">",
+	}
+
+	got, err := rb.DisplayContent(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "@ally @bob <@" + idUnknown + "> #general <#" + idHidden + "> @mods @ally"
+	if got != want {
+		t.Errorf("DisplayContent() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,242 @@
+package revolt
+
+// The copy methods return a copy that partial updates can be decoded into
+// without changing the original, which may still be used by handlers.
+
+func (m *Message) copy() *Message {
+	c := *m
+
+	c.Attachments = append([]*File(nil), m.Attachments...)
+	c.Mentions = append([]string(nil), m.Mentions...)
+	c.Replies = append([]string(nil), m.Replies...)
+
+	if m.Embeds != nil {
+		c.Embeds = make([]*Embed, len(m.Embeds))
+		for i, e := range m.Embeds {
+			c.Embeds[i] = e.copy()
+		}
+	}
+
+	if m.Masquerade != nil {
+		v := *m.Masquerade
+		c.Masquerade = &v
+	}
+
+	return &c
+}
+
+func (e *Embed) copy() *Embed {
+	c := *e
+
+	if e.Special != nil {
+		v := *e.Special
+		c.Special = &v
+	}
+
+	if e.Image != nil {
+		v := *e.Image
+		c.Image = &v
+	}
+
+	if e.Video != nil {
+		v := *e.Video
+		c.Video = &v
+	}
+
+	if e.Media != nil {
+		v := *e.Media
+		c.Media = &v
+	}
+
+	return &c
+}
+
+func (c *Channel) copy() *Channel {
+	n := *c
+
+	if c.SavedMessages != nil {
+		v := *c.SavedMessages
+		n.SavedMessages = &v
+	}
+
+	if c.DirectMessage != nil {
+		v := *c.DirectMessage
+		v.Recipients = append([]string(nil), v.Recipients...)
+		n.DirectMessage = &v
+	}
+
+	if c.Group != nil {
+		v := *c.Group
+		v.Recipients = append([]string(nil), v.Recipients...)
+		n.Group = &v
+	}
+
+	if c.TextChannel != nil {
+		v := *c.TextChannel
+		v.DefaultPermissions, v.RolePermissions = copyOverrides(v.DefaultPermissions, v.RolePermissions)
+		n.TextChannel = &v
+	}
+
+	if c.VoiceChannel != nil {
+		v := *c.VoiceChannel
+		v.DefaultPermissions, v.RolePermissions = copyOverrides(v.DefaultPermissions, v.RolePermissions)
+		n.VoiceChannel = &v
+	}
+
+	return &n
+}
+
+func copyOverrides(defaults *PermissionOverride, roles map[string]*PermissionOverride) (*PermissionOverride, map[string]*PermissionOverride) {
+	if defaults != nil {
+		o := *defaults
+		defaults = &o
+	}
+
+	if roles != nil {
+		n := make(map[string]*PermissionOverride, len(roles))
+		for id, o := range roles {
+			o := *o
+			n[id] = &o
+		}
+
+		roles = n
+	}
+
+	return defaults, roles
+}
+
+func (g *Guild) copy() *Guild {
+	c := *g
+
+	c.Channels = append([]string(nil), g.Channels...)
+	c.Categories = append([]*GuildCategory(nil), g.Categories...)
+
+	c.Roles = make(map[string]*GuildRole, len(g.Roles))
+	for id, r := range g.Roles {
+		c.Roles[id] = r
+	}
+
+	return &c
+}
+
+func (r *GuildRole) copy() *GuildRole {
+	c := *r
+
+	return &c
+}
+
+func (m *GuildMember) copy() *GuildMember {
+	c := *m
+
+	c.Roles = append([]string(nil), m.Roles...)
+
+	return &c
+}
+
+func (u *User) copy() *User {
+	c := *u
+
+	c.Relations = append([]*UserRelations(nil), u.Relations...)
+
+	if u.Status != nil {
+		status := *u.Status
+		c.Status = &status
+	}
+
+	return &c
+}
+
+// The clear methods reset the field named by the Clear of an update event.
+
+func (c *Channel) clear(field string) {
+	switch {
+	case c.Group != nil:
+		switch field {
+		case "Icon":
+			c.Group.Icon = nil
+		case "Description":
+			c.Group.Description = ""
+		}
+	case c.TextChannel != nil:
+		switch field {
+		case "Icon":
+			c.TextChannel.Icon = nil
+		case "Description":
+			c.TextChannel.Description = ""
+		}
+	case c.VoiceChannel != nil:
+		switch field {
+		case "Icon":
+			c.VoiceChannel.Icon = nil
+		case "Description":
+			c.VoiceChannel.Description = ""
+		}
+	}
+}
+
+func (g *Guild) clear(field string) {
+	switch field {
+	case "Icon":
+		g.Icon = nil
+	case "Banner":
+		g.Banner = nil
+	case "Description":
+		g.Description = ""
+	}
+}
+
+func (r *GuildRole) clear(field string) {
+	switch field {
+	case "Colour":
+		r.Colour = ""
+	}
+}
+
+func (m *GuildMember) clear(field string) {
+	switch field {
+	case "Nickname":
+		m.Nickname = ""
+	case "Avatar":
+		m.Avatar = nil
+	}
+}
+
+func (u *User) clear(field string) {
+	switch field {
+	case "Avatar":
+		u.Avatar = nil
+	case "StatusText":
+		if u.Status != nil {
+			u.Status.CustomStatus = ""
+		}
+	}
+}
+
+// memberKey returns the key of a member in MapCache and BoltCache.
+func memberKey(guildID string, userID string) string {
+	return guildID + ":" + userID
+}
+
+// addString returns s with v appended, unless s already contains it.
+func addString(s []string, v string) []string {
+	for _, i := range s {
+		if i == v {
+			return s
+		}
+	}
+
+	return append(s, v)
+}
+
+// removeString returns a copy of s without any occurrence of v.
+func removeString(s []string, v string) []string {
+	n := make([]string, 0, len(s))
+
+	for _, i := range s {
+		if i != v {
+			n = append(n, i)
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,121 @@
+package revolt
+
+import "sync"
+
+// Number of messages cached per channel by default.
+const DefaultMessageCacheSize = 100
+
+// messageCache keeps the last size messages of every channel.
+type messageCache struct {
+	mu   sync.Mutex
+	size int
+
+	messages map[string]*Message
+
+	// IDs of the cached messages in every channel, oldest first.
+	channels map[string][]string
+}
+
+func newMessageCache(size int) *messageCache {
+	return &messageCache{
+		size:     size,
+		messages: make(map[string]*Message),
+		channels: make(map[string][]string),
+	}
+}
+
+// add caches a message, evicting the oldest message of the channel if it
+// is full.
+func (mc *messageCache) add(m *Message) {
+	if mc.size <= 0 {
+		return
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, ok := mc.messages[m.ID]; ok {
+		mc.messages[m.ID] = m
+
+		return
+	}
+
+	ids := append(mc.channels[m.ChannelID], m.ID)
+	if len(ids) > mc.size {
+		delete(mc.messages, ids[0])
+		ids = ids[1:]
+	}
+
+	mc.channels[m.ChannelID] = ids
+	mc.messages[m.ID] = m
+}
+
+func (mc *messageCache) get(messageID string) (m *Message, ok bool) {
+	mc.mu.Lock()
+	m, ok = mc.messages[messageID]
+	mc.mu.Unlock()
+
+	return m, ok
+}
+
+// update replaces a message if it is still cached.
+func (mc *messageCache) update(m *Message) {
+	mc.mu.Lock()
+	if _, ok := mc.messages[m.ID]; ok {
+		mc.messages[m.ID] = m
+	}
+	mc.mu.Unlock()
+}
+
+func (mc *messageCache) remove(messageID string) (m *Message, ok bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	m, ok = mc.messages[messageID]
+	if !ok {
+		return nil, false
+	}
+
+	delete(mc.messages, messageID)
+
+	ids := mc.channels[m.ChannelID]
+	for i, id := range ids {
+		if id == messageID {
+			mc.channels[m.ChannelID] = append(ids[:i:i], ids[i+1:]...)
+
+			break
+		}
+	}
+
+	return m, true
+}
+
+// removeChannel drops every cached message of a channel.
+func (mc *messageCache) removeChannel(channelID string) {
+	mc.mu.Lock()
+	for _, id := range mc.channels[channelID] {
+		delete(mc.messages, id)
+	}
+	delete(mc.channels, channelID)
+	mc.mu.Unlock()
+}
+
+// Message returns a message from the message cache, if it is still cached.
+func (rb *RevoltBot) Message(messageID string) (message *Message, ok bool) {
+	return rb.messages.get(messageID)
+}
+
+// ChannelMessages returns the cached messages of a channel, oldest first.
+func (rb *RevoltBot) ChannelMessages(channelID string) (messages []*Message) {
+	rb.messages.mu.Lock()
+	defer rb.messages.mu.Unlock()
+
+	ids := rb.messages.channels[channelID]
+
+	messages = make([]*Message, 0, len(ids))
+	for _, id := range ids {
+		messages = append(messages, rb.messages.messages[id])
+	}
+
+	return messages
+}
@@ -0,0 +1,320 @@
+package revolt
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/vmihailenco/msgpack"
+)
+
+// Maximum number of messages that can be fetched or deleted at once.
+const MaxMessagesLimit = 100
+
+// Sort orders for FetchMessages.
+const (
+	MessageSortLatest    = "Latest"
+	MessageSortOldest    = "Oldest"
+	MessageSortRelevance = "Relevance"
+)
+
+type MessageEdit struct {
+	Content string `json:"content"`
+}
+
+// MessageQuery filters the messages returned by FetchMessages. Empty fields
+// are left out.
+type MessageQuery struct {
+	Limit  int
+	Before string
+	After  string
+	Sort   string
+
+	// Fetch the messages around this message ID, ignoring Before and After.
+	Nearby string
+}
+
+func (q *MessageQuery) values() url.Values {
+	v := url.Values{}
+
+	if q == nil {
+		return v
+	}
+
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	if q.Before != "" {
+		v.Set("before", q.Before)
+	}
+
+	if q.After != "" {
+		v.Set("after", q.After)
+	}
+
+	if q.Sort != "" {
+		v.Set("sort", q.Sort)
+	}
+
+	if q.Nearby != "" {
+		v.Set("nearby", q.Nearby)
+	}
+
+	return v
+}
+
+func (rb *RevoltBot) FetchMessage(channelID string, messageID string) (message *Message, err error) {
+	err = rb.request(http.MethodGet, "/channels/"+channelID+"/messages/"+messageID, nil, &message)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func (rb *RevoltBot) FetchMessages(channelID string, query *MessageQuery) (messages []*Message, err error) {
+	path := "/channels/" + channelID + "/messages"
+	if v := query.values(); len(v) > 0 {
+		path += "?" + v.Encode()
+	}
+
+	err = rb.request(http.MethodGet, path, nil, &messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (rb *RevoltBot) EditMessage(channelID string, messageID string, messageEdit *MessageEdit) (err error) {
+	return rb.request(http.MethodPatch, "/channels/"+channelID+"/messages/"+messageID, messageEdit, nil)
+}
+
+func (rb *RevoltBot) DeleteMessage(channelID string, messageID string) (err error) {
+	return rb.request(http.MethodDelete, "/channels/"+channelID+"/messages/"+messageID, nil, nil)
+}
+
+// BulkDeleteMessages deletes up to MaxMessagesLimit messages at once.
+func (rb *RevoltBot) BulkDeleteMessages(channelID string, messageIDs []string) (err error) {
+	return rb.request(http.MethodDelete, "/channels/"+channelID+"/messages/bulk", struct {
+		IDs []string `json:"ids"`
+	}{messageIDs}, nil)
+}
+
+// Reply returns a reply to the message, which pings its author if mention
+// is set.
+func (m *Message) Reply(mention bool) *Reply {
+	return &Reply{ID: m.ID, Mention: mention}
+}
+
+// ReplyTo adds a reply to the specified message to the request.
+func (mr *MessageRequest) ReplyTo(messageID string, mention bool) *MessageRequest {
+	mr.Replies = append(mr.Replies, &Reply{ID: messageID, Mention: mention})
+
+	return mr
+}
+
+// Reply sends a message in reply to another one, in the same channel.
+func (rb *RevoltBot) Reply(message *Message, messageRequest *MessageRequest, mention bool) (reply *Message, err error) {
+	messageRequest.Replies = append(messageRequest.Replies, message.Reply(mention))
+
+	return rb.SendMessage(message.ChannelID, messageRequest)
+}
+
+// MessageIterator lazily pages through the history of a channel, from the
+// newest message to the oldest.
+type MessageIterator struct {
+	rb        *RevoltBot
+	channelID string
+	pageSize  int
+
+	page    []*Message
+	message *Message
+	before  string
+	done    bool
+	err     error
+}
+
+// MessageHistory returns an iterator over the messages in a channel that
+// fetches pageSize messages at a time.
+func (rb *RevoltBot) MessageHistory(channelID string, pageSize int) *MessageIterator {
+	if pageSize <= 0 || pageSize > MaxMessagesLimit {
+		pageSize = MaxMessagesLimit
+	}
+
+	return &MessageIterator{
+		rb:        rb,
+		channelID: channelID,
+		pageSize:  pageSize,
+	}
+}
+
+// Next advances to the next message, fetching another page when needed. It
+// returns false once there are no more messages or an error occurred.
+func (it *MessageIterator) Next() bool {
+	if len(it.page) == 0 && !it.done {
+		it.page, it.err = it.rb.FetchMessages(it.channelID, &MessageQuery{
+			Limit:  it.pageSize,
+			Before: it.before,
+			Sort:   MessageSortLatest,
+		})
+
+		if it.err != nil || len(it.page) < it.pageSize {
+			it.done = true
+		}
+
+		if len(it.page) > 0 {
+			it.before = it.page[len(it.page)-1].ID
+		}
+	}
+
+	if len(it.page) == 0 {
+		it.message = nil
+
+		return false
+	}
+
+	it.message, it.page = it.page[0], it.page[1:]
+
+	return true
+}
+
+// Message returns the current message.
+func (it *MessageIterator) Message() *Message {
+	return it.message
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *MessageIterator) Err() error {
+	return it.err
+}
+
+// messageFields is Message without its decoders, so the fields other than
+// the content can be decoded as usual.
+type messageFields Message
+
+// UnmarshalJSON decodes a message, setting Content or System depending on
+// the type of its content. Content is left as is if it is missing, such as
+// in partial updates.
+func (m *Message) UnmarshalJSON(data []byte) (err error) {
+	err = json.Unmarshal(data, (*messageFields)(m))
+	if err != nil {
+		return err
+	}
+
+	var content struct {
+		Content jsoniter.RawMessage `json:"content"`
+	}
+
+	err = json.Unmarshal(data, &content)
+	if err != nil {
+		return err
+	}
+
+	raw := bytes.TrimSpace(content.Content)
+
+	switch {
+	case len(raw) == 0 || bytes.Equal(raw, []byte("null")):
+	case raw[0] == '"':
+		m.System = nil
+
+		return json.Unmarshal(raw, &m.Content)
+	default:
+		m.Content = ""
+		m.System = &SystemMessage{}
+
+		return json.Unmarshal(raw, m.System)
+	}
+
+	return nil
+}
+
+// DecodeMsgpack is the msgpack counterpart of UnmarshalJSON.
+func (m *Message) DecodeMsgpack(dec *msgpack.Decoder) (err error) {
+	raw, err := reencodeMsgpack(dec)
+	if err != nil {
+		return err
+	}
+
+	return m.decodeMsgpack(raw)
+}
+
+func (m *Message) decodeMsgpack(raw []byte) (err error) {
+	err = msgpackCodec{}.Unmarshal(raw, (*messageFields)(m))
+	if err != nil {
+		return err
+	}
+
+	var content struct {
+		Content interface{} `json:"content"`
+	}
+
+	err = msgpackCodec{}.Unmarshal(raw, &content)
+	if err != nil {
+		return err
+	}
+
+	switch v := content.Content.(type) {
+	case string:
+		m.Content = v
+		m.System = nil
+	case map[string]interface{}:
+		system, err := msgpack.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		m.Content = ""
+		m.System = &SystemMessage{}
+
+		return msgpackCodec{}.Unmarshal(system, m.System)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the message with its content the way the API sends
+// it.
+func (m *Message) MarshalJSON() (data []byte, err error) {
+	var content interface{} = m.Content
+	if m.System != nil {
+		content = m.System
+	}
+
+	return json.Marshal(struct {
+		*messageFields
+		Content interface{} `json:"content"`
+	}{(*messageFields)(m), content})
+}
+
+// UnmarshalJSON decodes the event itself, as the decoders promoted from the
+// embedded *Message would be called on a nil pointer.
+func (o *MessageCreate) UnmarshalJSON(data []byte) (err error) {
+	err = json.Unmarshal(data, &o.SentBase)
+	if err != nil {
+		return err
+	}
+
+	o.Message = &Message{}
+
+	return o.Message.UnmarshalJSON(data)
+}
+
+func (o *MessageCreate) DecodeMsgpack(dec *msgpack.Decoder) (err error) {
+	raw, err := reencodeMsgpack(dec)
+	if err != nil {
+		return err
+	}
+
+	err = msgpackCodec{}.Unmarshal(raw, &o.SentBase)
+	if err != nil {
+		return err
+	}
+
+	o.Message = &Message{}
+
+	return o.Message.decodeMsgpack(raw)
+}
@@ -0,0 +1,366 @@
+package revolt
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestFetchMessagesQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *MessageQuery
+		want  url.Values
+	}{
+		{
+			name: "nil",
+			want: url.Values{},
+		},
+		{
+			name:  "empty",
+			query: &MessageQuery{},
+			want:  url.Values{},
+		},
+		{
+			name:  "page",
+			query: &MessageQuery{Limit: 50, Before: "b", After: "a", Sort: MessageSortOldest},
+			want:  url.Values{"limit": {"50"}, "before": {"b"}, "after": {"a"}, "sort": {"Oldest"}},
+		},
+		{
+			name:  "nearby",
+			query: &MessageQuery{Nearby: "n"},
+			want:  url.Values{"nearby": {"n"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got url.Values
+
+			rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/channels/channel/messages" {
+					t.Errorf("request to %s %s", r.Method, r.URL.Path)
+				}
+
+				got = r.URL.Query()
+
+				w.Write([]byte(`[{"_id": "message", "content": "hi"}]`))
+			}))
+
+			messages, err := rb.FetchMessages("channel", test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(messages) != 1 || messages[0].Content != "hi" {
+				t.Errorf("FetchMessages() = %+v", messages)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("query = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBulkDeleteMessages(t *testing.T) {
+	var body []byte
+
+	rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/channels/channel/messages/bulk" {
+			t.Errorf("request to %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ = ioutil.ReadAll(r.Body)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	err := rb.BulkDeleteMessages("channel", []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		IDs []string `json:"ids"`
+	}
+
+	if err := json.Unmarshal(body, &got); err != nil || !reflect.DeepEqual(got.IDs, []string{"a", "b"}) {
+		t.Errorf("body = %s", body)
+	}
+}
+
+// newHistoryBot returns a bot whose API serves a channel holding count
+// messages, numbered from the oldest, and a function returning the queries
+// it received.
+func newHistoryBot(t *testing.T, count int) (rb *RevoltBot, queries func() []url.Values) {
+	var mu sync.Mutex
+
+	var received []url.Values
+
+	rb = newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		mu.Lock()
+		received = append(received, q)
+		mu.Unlock()
+
+		if q.Get("sort") != MessageSortLatest {
+			t.Errorf("sort = %q, want Latest", q.Get("sort"))
+		}
+
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		newest := count
+		if before := q.Get("before"); before != "" {
+			newest, _ = strconv.Atoi(before)
+			newest--
+		}
+
+		messages := []*Message{}
+		for id := newest; id > 0 && len(messages) < limit; id-- {
+			messages = append(messages, &Message{ID: strconv.Itoa(id)})
+		}
+
+		data, _ := json.Marshal(messages)
+		w.Write(data)
+	}))
+
+	return rb, func() []url.Values {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return received
+	}
+}
+
+func TestMessageIterator(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		pageSize int
+		requests int
+	}{
+		{name: "partial last page", count: 5, pageSize: 2, requests: 3},
+		{name: "full last page", count: 4, pageSize: 2, requests: 3},
+		{name: "empty channel", count: 0, pageSize: 2, requests: 1},
+		{name: "single page", count: 3, pageSize: 10, requests: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rb, queries := newHistoryBot(t, test.count)
+
+			it := rb.MessageHistory("channel", test.pageSize)
+
+			var ids []string
+			for it.Next() {
+				ids = append(ids, it.Message().ID)
+			}
+
+			if err := it.Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			var want []string
+			for id := test.count; id > 0; id-- {
+				want = append(want, strconv.Itoa(id))
+			}
+
+			if !reflect.DeepEqual(ids, want) {
+				t.Errorf("iterated over %v, want %v", ids, want)
+			}
+
+			// The iterator stays done.
+			if it.Next() || it.Message() != nil {
+				t.Error("Next() = true after the last message")
+			}
+
+			if n := len(queries()); n != test.requests {
+				t.Errorf("fetched %d pages, want %d", n, test.requests)
+			}
+
+			for i, q := range queries() {
+				if q.Get("limit") != strconv.Itoa(test.pageSize) {
+					t.Errorf("page %d limit = %q", i, q.Get("limit"))
+				}
+
+				if i > 0 && q.Get("before") != ids[i*test.pageSize-1] {
+					t.Errorf("page %d before = %q, want the last message of the previous page", i, q.Get("before"))
+				}
+			}
+		})
+	}
+}
+
+func TestMessageIteratorError(t *testing.T) {
+	rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"type": "MissingPermission"}`))
+	}))
+
+	it := rb.MessageHistory("channel", 0)
+
+	if it.Next() {
+		t.Fatal("Next() = true after a failed request")
+	}
+
+	apiErr, ok := it.Err().(*APIError)
+	if !ok || apiErr.Type != "MissingPermission" {
+		t.Errorf("Err() = %v, want the API error", it.Err())
+	}
+}
+
+func TestMessageContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *Message
+	}{
+		{
+			name:    "text",
+			content: `"hello"`,
+			want:    &Message{Content: "hello"},
+		},
+		{
+			name:    "missing",
+			content: `null`,
+			want:    &Message{},
+		},
+		{
+			name:    SystemMessageText,
+			content: `{"type": "text", "content": "hello"}`,
+			want:    &Message{System: &SystemMessage{Type: SystemMessageText, Content: "hello"}},
+		},
+		{
+			name:    SystemMessageUserAdded,
+			content: `{"type": "user_added", "id": "user", "by": "owner"}`,
+			want:    &Message{System: &SystemMessage{Type: SystemMessageUserAdded, ID: "user", By: "owner"}},
+		},
+		{
+			name:    SystemMessageUserRemove,
+			content: `{"type": "user_remove", "id": "user", "by": "owner"}`,
+			want:    &Message{System: &SystemMessage{Type: SystemMessageUserRemove, ID: "user", By: "owner"}},
+		},
+		{
+			name:    SystemMessageUserJoined,
+			content: `{"type": "user_joined", "id": "user"}`,
+			want:    &Message{System: &SystemMessage{Type: SystemMessageUserJoined, ID: "user"}},
+		},
+		{
+			name:    SystemMessageUserLeft,
+			content: `{"type": "user_left", "id": "user"}`,
+			want:    &Message{System: &SystemMessage{Type: SystemMessageUserLeft, ID: "user"}},
+		},
+		{
+			name:    SystemMessageUserKicked,
+			content: `{"type": "user_kicked", "id": "user"}`,
+			want:    &Message{System: &SystemMessage{Type: SystemMessageUserKicked, ID: "user"}},
+		},
+		{
+			name:    SystemMessageUserBanned,
+			content: `{"type": "user_banned", "id": "user"}`,
+			want:    &Message{System: &SystemMessage{Type: SystemMessageUserBanned, ID: "user"}},
+		},
+		{
+			name:    SystemMessageChannelRenamed,
+			content: `{"type": "channel_renamed", "name": "general", "by": "owner"}`,
+			want:    &Message{System: &SystemMessage{Type: SystemMessageChannelRenamed, Name: "general", By: "owner"}},
+		},
+		{
+			name:    SystemMessageChannelDescriptionChanged,
+			content: `{"type": "channel_description_changed", "by": "owner"}`,
+			want:    &Message{System: &SystemMessage{Type: SystemMessageChannelDescriptionChanged, By: "owner"}},
+		},
+		{
+			name:    SystemMessageChannelIconChanged,
+			content: `{"type": "channel_icon_changed", "by": "owner"}`,
+			want:    &Message{System: &SystemMessage{Type: SystemMessageChannelIconChanged, By: "owner"}},
+		},
+	}
+
+	for _, test := range tests {
+		want := *test.want
+		want.ID = "message"
+		want.ChannelID = "channel"
+		want.Author = "user"
+
+		frame := `{"type": "Message", "_id": "message", "channel": "channel", "author": "user", "content": ` + test.content + `}`
+
+		for _, c := range codecs {
+			t.Run(test.name+"/"+c.Format(), func(t *testing.T) {
+				o := decodeFrame(t, c, frame).(MessageCreate)
+				if o.Type != EventTypeMessage || !reflect.DeepEqual(o.Message, &want) {
+					t.Fatalf("MessageCreate = %+v, want %+v", o.Message, &want)
+				}
+
+				// Messages are encoded the way the API sends them.
+				data, err := json.Marshal(o.Message)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got := &Message{}
+				if err := json.Unmarshal(data, got); err != nil || !reflect.DeepEqual(got, &want) {
+					t.Errorf("round trip through %s gave %+v", data, got)
+				}
+			})
+		}
+	}
+}
+
+func TestMessageUpdateContent(t *testing.T) {
+	tests := []struct {
+		name   string
+		create string
+		update string
+		want   *Message
+	}{
+		{
+			name:   "partial",
+			create: `"hello"`,
+			update: `{"edited": 1}`,
+			want:   &Message{Content: "hello", Edited: 1},
+		},
+		{
+			name:   "partial system",
+			create: `{"type": "user_joined", "id": "user"}`,
+			update: `{"edited": 1}`,
+			want:   &Message{System: &SystemMessage{Type: SystemMessageUserJoined, ID: "user"}, Edited: 1},
+		},
+		{
+			name:   "content",
+			create: `"hello"`,
+			update: `{"content": "edited", "edited": 1}`,
+			want:   &Message{Content: "edited", Edited: 1},
+		},
+	}
+
+	for _, test := range tests {
+		want := *test.want
+		want.ID = "message"
+		want.ChannelID = "channel"
+		want.Author = "user"
+
+		for _, c := range codecs {
+			t.Run(test.name+"/"+c.Format(), func(t *testing.T) {
+				rb := NewRevoltBot("")
+				rb.codec = c
+
+				applyFrames(t, rb,
+					`{"type": "Message", "_id": "message", "channel": "channel", "author": "user", "content": `+test.create+`}`,
+					`{"type": "MessageUpdate", "id": "message", "data": `+test.update+`}`,
+				)
+
+				got, ok := rb.messages.get("message")
+				if !ok || !reflect.DeepEqual(got, &want) {
+					t.Errorf("cached message = %+v, want %+v", got, &want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package revolt
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Option configures a RevoltBot created with NewRevoltBot.
+type Option func(rb *RevoltBot)
+
+// WithBaseURL sets the URL of the API, such as a self-hosted instance. The
+// websocket and Autumn URLs are discovered from it unless also set.
+func WithBaseURL(baseURL string) Option {
+	return func(rb *RevoltBot) {
+		rb.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithWebsocketURL sets the URL of the gateway.
+func WithWebsocketURL(wsURL string) Option {
+	return func(rb *RevoltBot) {
+		rb.wsURL = wsURL
+	}
+}
+
+// WithAutumnURL sets the URL of the file server.
+func WithAutumnURL(autumnURL string) Option {
+	return func(rb *RevoltBot) {
+		rb.autumnURL = strings.TrimSuffix(autumnURL, "/")
+	}
+}
+
+// WithHTTPClient sets the client used for REST requests and dialing the
+// gateway.
+func WithHTTPClient(client *http.Client) Option {
+	return func(rb *RevoltBot) {
+		rb.httpClient = client
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(rb *RevoltBot) {
+		rb.userAgent = userAgent
+	}
+}
+
+// WithMsgpack makes the gateway connection use MessagePack instead of JSON.
+func WithMsgpack() Option {
+	return func(rb *RevoltBot) {
+		rb.Msgpack = true
+	}
+}
+
+// WithMessageCacheSize sets how many messages are cached per channel. A
+// size of 0 disables the message cache.
+func WithMessageCacheSize(size int) Option {
+	return func(rb *RevoltBot) {
+		rb.messages = newMessageCache(size)
+	}
+}
+
+// WithCache sets the Cache the state is stored in. By default a MapCache is
+// used.
+func WithCache(cache Cache) Option {
+	return func(rb *RevoltBot) {
+		rb.State = cache
+	}
+}
+
+// WithCacheFlags only caches the kinds of state in flags.
+func WithCacheFlags(flags CacheFlags) Option {
+	return func(rb *RevoltBot) {
+		rb.cacheFlags = flags
+	}
+}
+
+// WithSnapshot loads the cache from the snapshot at path when the bot is
+// started and saves it there again when it is shut down.
+func WithSnapshot(path string) Option {
+	return func(rb *RevoltBot) {
+		rb.snapshotPath = path
+	}
+}
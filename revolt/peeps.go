@@ -0,0 +1,366 @@
+package revolt
+
+type User struct {
+	ID           string           `json:"_id"`
+	Username     string           `json:"username"`
+	Avatar       *File            `json:"avatar"`
+	Relations    []*UserRelations `json:"relations"`
+	Badges       int              `json:"badges"`
+	Status       *UserStatus      `json:"status"`
+	Bot          *UserBot         `json:"bot,omitempty"`
+	Relationship string           `json:"relationship"`
+	Online       bool             `json:"online"`
+	Flags        int              `json:"flags"`
+}
+
+type UserRelations struct {
+	Status string `json:"status"`
+	UserID string `json:"_id"`
+}
+
+type File struct {
+	ID          string    `json:"_id"`
+	Tag         string    `json:"tag"`
+	Size        int       `json:"size"`
+	Filename    string    `json:"filename"`
+	Metadata    *Metadata `json:"metadata"`
+	ContentType string    `json:"content_type"`
+}
+
+type UserStatus struct {
+	CustomStatus string `json:"text"`
+	Presence     string `json:"presence"`
+}
+
+type Metadata struct {
+	Type   string `json:"type"`
+	Width  *int   `json:"width,omitempty"`
+	Height *int   `json:"height,omitempty"`
+}
+
+type UserBot struct {
+	Owner string `json:"owner"`
+}
+
+type Guild struct {
+	ID                 string                `json:"_id"`
+	Nonce              string                `json:"nonce"`
+	Owner              string                `json:"owner"`
+	Name               string                `json:"name"`
+	Description        string                `json:"description"`
+	Channels           []string              `json:"channels"`
+	Categories         []*GuildCategory      `json:"categories"`
+	Roles              map[string]*GuildRole `json:"roles"`
+	SystemMessages     *GuildSystemMessages  `json:"system_messages"`
+	DefaultPermissions PermissionSet         `json:"default_permissions"`
+
+	Icon   *File `json:"icon"`
+	Banner *File `json:"banner"`
+}
+
+type GuildCategory struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Channels []string `json:"channels"`
+}
+
+type GuildRole struct {
+	Name        string        `json:"name"`
+	Permissions PermissionSet `json:"permissions"`
+	Colour      string        `json:"colour"`
+	Hoist       bool          `json:"hoist"`
+	Rank        int           `json:"rank"`
+}
+
+type GuildMember struct {
+	ID       *GuildMemberIDs `json:"_id"`
+	Nickname string          `json:"nickname"`
+	Avatar   *File           `json:"avatar"`
+	Roles    []string        `json:"roles"`
+
+	// ISO 8601 timestamp of when the member joined the server. Members
+	// cached from ServerMemberJoin, which does not include it, have the time
+	// the event was received instead.
+	JoinedAt string `json:"joined_at"`
+}
+
+type GuildMemberIDs struct {
+	Server string `json:"server"`
+	User   string `json:"user"`
+}
+
+type GuildSystemMessages struct {
+	UserJoined string `json:"user_joined"`
+	UserLeft   string `json:"user_left"`
+	UserKicked string `json:"user_kicked"`
+	UserBanned string `json:"user_banned"`
+}
+
+// Types of channel, set in Channel.ChannelType.
+const (
+	ChannelTypeSavedMessages = "SavedMessages"
+	ChannelTypeDirectMessage = "DirectMessage"
+	ChannelTypeGroup         = "Group"
+	ChannelTypeText          = "TextChannel"
+	ChannelTypeVoice         = "VoiceChannel"
+)
+
+// Channel is any type of channel. The fields specific to its type are in
+// the one field matching ChannelType, the others are nil.
+type Channel struct {
+	ID          string
+	ChannelType string
+	Nonce       string
+
+	SavedMessages *SavedMessages
+	DirectMessage *DirectMessage
+	Group         *Group
+	TextChannel   *TextChannel
+	VoiceChannel  *VoiceChannel
+}
+
+// SavedMessages is the channel only its user can see.
+type SavedMessages struct {
+	User string `json:"user"`
+}
+
+type DirectMessage struct {
+	Active        bool     `json:"active"`
+	Recipients    []string `json:"recipients"`
+	LastMessageID string   `json:"last_message_id,omitempty"`
+}
+
+type Group struct {
+	Name          string   `json:"name"`
+	Owner         string   `json:"owner"`
+	Description   string   `json:"description"`
+	Recipients    []string `json:"recipients"`
+	Icon          *File    `json:"icon"`
+	LastMessageID string   `json:"last_message_id,omitempty"`
+	NSFW          bool     `json:"nsfw"`
+
+	// Permissions of the recipients other than the owner, if set.
+	Permissions *ChannelPermission `json:"permissions,omitempty"`
+}
+
+type TextChannel struct {
+	Server        string `json:"server"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Icon          *File  `json:"icon"`
+	LastMessageID string `json:"last_message_id,omitempty"`
+	NSFW          bool   `json:"nsfw"`
+
+	// Overrides of the server permissions, for everyone and per role.
+	DefaultPermissions *PermissionOverride            `json:"default_permissions,omitempty"`
+	RolePermissions    map[string]*PermissionOverride `json:"role_permissions,omitempty"`
+}
+
+type VoiceChannel struct {
+	Server      string `json:"server"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        *File  `json:"icon"`
+	NSFW        bool   `json:"nsfw"`
+
+	// Overrides of the server permissions, for everyone and per role.
+	DefaultPermissions *PermissionOverride            `json:"default_permissions,omitempty"`
+	RolePermissions    map[string]*PermissionOverride `json:"role_permissions,omitempty"`
+}
+
+type Message struct {
+	ID        string `json:"_id"`
+	Nonce     string `json:"nonce"`
+	ChannelID string `json:"channel"`
+	Author    string `json:"author"`
+
+	// Text of the message. Content is a string for messages sent by users
+	// and an object for system messages, so it is decoded by the decoders
+	// of Message into either Content or System.
+	Content string `json:"-"`
+
+	// Set instead of Content for messages sent by the system.
+	System *SystemMessage `json:"-"`
+
+	Attachments []*File  `json:"attachments"`
+	Edited      int      `json:"edited"`
+	Mentions    []string `json:"mentions"`
+	Replies     []string `json:"replies"`
+
+	Embeds     []*Embed    `json:"embeds"`
+	Masquerade *Masquerade `json:"masquerade"`
+}
+
+type MessageRequest struct {
+	Content     string   `json:"content"`
+	Nonce       string   `json:"nonce"`
+	Attachments []string `json:"attachments"`
+	Replies     []*Reply `json:"replies"`
+
+	Embeds     []*SendableEmbed `json:"embeds,omitempty"`
+	Masquerade *Masquerade      `json:"masquerade,omitempty"`
+}
+
+type Reply struct {
+	ID      string `json:"id"`
+	Mention bool   `json:"mention"`
+}
+
+// Masquerade overrides the name and avatar shown for a message.
+type Masquerade struct {
+	Name string `json:"name,omitempty"`
+
+	// URL of the avatar.
+	Avatar string `json:"avatar,omitempty"`
+}
+
+// Types of embed.
+const (
+	EmbedTypeNone    = "None"
+	EmbedTypeWebsite = "Website"
+	EmbedTypeImage   = "Image"
+	EmbedTypeVideo   = "Video"
+	EmbedTypeText    = "Text"
+)
+
+// Sizes of image embeds.
+const (
+	EmbedImageSizeLarge   = "Large"
+	EmbedImageSizePreview = "Preview"
+)
+
+// Embed is an embed of a received message. Text embeds are sent by bots,
+// the other types are generated by January, the proxy of Revolt, from the
+// links in a message. Which fields are set depends on Type.
+type Embed struct {
+	Type string `json:"type"`
+
+	// Website, Image, Video and Text
+	URL string `json:"url,omitempty"`
+
+	// Website
+	OriginalURL string        `json:"original_url,omitempty"`
+	Special     *EmbedSpecial `json:"special,omitempty"`
+	SiteName    string        `json:"site_name,omitempty"`
+	Image       *EmbedImage   `json:"image,omitempty"`
+	Video       *EmbedVideo   `json:"video,omitempty"`
+
+	// Website and Text
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	IconURL     string `json:"icon_url,omitempty"`
+	Colour      string `json:"colour,omitempty"`
+
+	// Text
+	Media *File `json:"media,omitempty"`
+
+	// Image and Video
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Size   string `json:"size,omitempty"`
+}
+
+type EmbedImage struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Size   string `json:"size"`
+}
+
+type EmbedVideo struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// EmbedSpecial describes the content of a website January recognises, such
+// as a YouTube video or a Spotify track.
+type EmbedSpecial struct {
+	Type        string `json:"type"`
+	ID          string `json:"id,omitempty"`
+	Timestamp   string `json:"timestamp,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// SendableEmbed is a text embed sent with a message.
+type SendableEmbed struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	IconURL     string `json:"icon_url,omitempty"`
+
+	// CSS colour, such as "#FD4453".
+	Colour string `json:"colour,omitempty"`
+
+	// Autumn ID of an uploaded attachment.
+	Media string `json:"media,omitempty"`
+}
+
+// Types of system message.
+const (
+	SystemMessageText                      = "text"
+	SystemMessageUserAdded                 = "user_added"
+	SystemMessageUserRemove                = "user_remove"
+	SystemMessageUserJoined                = "user_joined"
+	SystemMessageUserLeft                  = "user_left"
+	SystemMessageUserKicked                = "user_kicked"
+	SystemMessageUserBanned                = "user_banned"
+	SystemMessageChannelRenamed            = "channel_renamed"
+	SystemMessageChannelDescriptionChanged = "channel_description_changed"
+	SystemMessageChannelIconChanged        = "channel_icon_changed"
+)
+
+// SystemMessage is a message sent by the system. Which fields are set
+// depends on Type.
+type SystemMessage struct {
+	Type string `json:"type"`
+
+	// text
+	Content string `json:"content,omitempty"`
+
+	// user_added, user_remove, user_joined, user_left, user_kicked and
+	// user_banned: the user the message is about.
+	ID string `json:"id,omitempty"`
+
+	// user_added, user_remove, channel_renamed,
+	// channel_description_changed and channel_icon_changed: the user that
+	// made the change.
+	By string `json:"by,omitempty"`
+
+	// channel_renamed
+	Name string `json:"name,omitempty"`
+}
+
+type NodeInfo struct {
+	Revolt   string        `json:"revolt"`
+	Features *NodeFeatures `json:"features"`
+	WS       string        `json:"ws"`
+	App      string        `json:"app"`
+	Vapid    string        `json:"vapid"`
+}
+
+type NodeFeatures struct {
+	Registration bool         `json:"registration"`
+	Email        bool         `json:"email"`
+	InviteOnly   bool         `json:"invite_only"`
+	Captcha      *NodeCaptcha `json:"captcha"`
+	Autumn       *NodeService `json:"autumn"`
+	January      *NodeService `json:"january"`
+	Voso         *NodeVoso    `json:"voso"`
+}
+
+type NodeCaptcha struct {
+	Enabled bool   `json:"enabled"`
+	Key     string `json:"key"`
+}
+
+type NodeService struct {
+	Enabled bool   `json:"enabled"`
+	URL     string `json:"url"`
+}
+
+type NodeVoso struct {
+	Enabled bool   `json:"enabled"`
+	URL     string `json:"url"`
+	WS      string `json:"ws"`
+}
@@ -0,0 +1,316 @@
+package revolt
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+
+	"github.com/vmihailenco/msgpack"
+)
+
+// ServerPermission is a bitfield of the permissions a member has in a
+// server.
+type ServerPermission uint32
+
+const (
+	ServerPermissionView ServerPermission = 1 << iota
+	ServerPermissionManageRoles
+	ServerPermissionManageChannels
+	ServerPermissionManageServer
+	ServerPermissionKickMembers
+	ServerPermissionBanMembers
+)
+
+const (
+	ServerPermissionChangeNickname ServerPermission = 1 << (iota + 12)
+	ServerPermissionManageNicknames
+	ServerPermissionChangeAvatar
+	ServerPermissionRemoveAvatars
+)
+
+const ServerPermissionAll = ServerPermissionView | ServerPermissionManageRoles |
+	ServerPermissionManageChannels | ServerPermissionManageServer |
+	ServerPermissionKickMembers | ServerPermissionBanMembers |
+	ServerPermissionChangeNickname | ServerPermissionManageNicknames |
+	ServerPermissionChangeAvatar | ServerPermissionRemoveAvatars
+
+// Has reports if every permission in perms is set.
+func (p ServerPermission) Has(perms ServerPermission) bool {
+	return p&perms == perms
+}
+
+// ChannelPermission is a bitfield of the permissions a user has in a
+// channel.
+type ChannelPermission uint32
+
+const (
+	ChannelPermissionView ChannelPermission = 1 << iota
+	ChannelPermissionSendMessage
+	ChannelPermissionManageMessages
+	ChannelPermissionManageChannel
+	ChannelPermissionVoiceCall
+	ChannelPermissionInviteOthers
+	ChannelPermissionEmbedLinks
+	ChannelPermissionUploadFiles
+)
+
+const ChannelPermissionAll = ChannelPermissionView | ChannelPermissionSendMessage |
+	ChannelPermissionManageMessages | ChannelPermissionManageChannel |
+	ChannelPermissionVoiceCall | ChannelPermissionInviteOthers |
+	ChannelPermissionEmbedLinks | ChannelPermissionUploadFiles
+
+// Has reports if every permission in perms is set.
+func (p ChannelPermission) Has(perms ChannelPermission) bool {
+	return p&perms == perms
+}
+
+// PermissionSet holds the server and channel permissions granted by a role,
+// or to everyone in a server. The API sends it as a [server, channel]
+// array.
+type PermissionSet struct {
+	Server  ServerPermission
+	Channel ChannelPermission
+}
+
+func (p PermissionSet) MarshalJSON() (data []byte, err error) {
+	return json.Marshal([2]uint32{uint32(p.Server), uint32(p.Channel)})
+}
+
+func (p *PermissionSet) UnmarshalJSON(data []byte) (err error) {
+	var perms []uint32
+
+	err = json.Unmarshal(data, &perms)
+	if err != nil {
+		return err
+	}
+
+	*p = PermissionSet{}
+
+	if len(perms) > 0 {
+		p.Server = ServerPermission(perms[0])
+	}
+
+	if len(perms) > 1 {
+		p.Channel = ChannelPermission(perms[1])
+	}
+
+	return nil
+}
+
+// EncodeMsgpack is the msgpack counterpart of MarshalJSON.
+func (p PermissionSet) EncodeMsgpack(enc *msgpack.Encoder) (err error) {
+	return enc.Encode([2]uint32{uint32(p.Server), uint32(p.Channel)})
+}
+
+// DecodeMsgpack is the msgpack counterpart of UnmarshalJSON.
+func (p *PermissionSet) DecodeMsgpack(dec *msgpack.Decoder) (err error) {
+	v, err := dec.DecodeInterfaceLoose()
+	if err != nil {
+		return err
+	}
+
+	*p = PermissionSet{}
+
+	perms, _ := v.([]interface{})
+
+	if len(perms) > 0 {
+		p.Server = ServerPermission(toUint64(perms[0]))
+	}
+
+	if len(perms) > 1 {
+		p.Channel = ChannelPermission(toUint64(perms[1]))
+	}
+
+	return nil
+}
+
+// PermissionOverride changes the permissions of a channel, either for
+// everyone or for the members of a role.
+type PermissionOverride struct {
+	Allow ChannelPermission `json:"a"`
+	Deny  ChannelPermission `json:"d"`
+}
+
+// apply returns perms with the override applied. A nil override leaves
+// perms unchanged.
+func (o *PermissionOverride) apply(perms ChannelPermission) ChannelPermission {
+	if o == nil {
+		return perms
+	}
+
+	return (perms | o.Allow) &^ o.Deny
+}
+
+// UnmarshalJSON decodes an override from either an object or a bare
+// number. A bare number, as sent by older versions of the API, grants
+// exactly the permissions it contains.
+func (o *PermissionOverride) UnmarshalJSON(data []byte) (err error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		type override PermissionOverride
+
+		return json.Unmarshal(data, (*override)(o))
+	}
+
+	var perms ChannelPermission
+
+	err = json.Unmarshal(data, &perms)
+	if err != nil {
+		return err
+	}
+
+	*o = newLegacyOverride(perms)
+
+	return nil
+}
+
+// DecodeMsgpack is the msgpack counterpart of UnmarshalJSON.
+func (o *PermissionOverride) DecodeMsgpack(dec *msgpack.Decoder) (err error) {
+	v, err := dec.DecodeInterfaceLoose()
+	if err != nil {
+		return err
+	}
+
+	if m, ok := v.(map[string]interface{}); ok {
+		*o = PermissionOverride{
+			Allow: ChannelPermission(toUint64(m["a"])),
+			Deny:  ChannelPermission(toUint64(m["d"])),
+		}
+
+		return nil
+	}
+
+	*o = newLegacyOverride(ChannelPermission(toUint64(v)))
+
+	return nil
+}
+
+func newLegacyOverride(perms ChannelPermission) PermissionOverride {
+	return PermissionOverride{
+		Allow: perms,
+		Deny:  ChannelPermissionAll &^ perms,
+	}
+}
+
+// toUint64 converts a number decoded from msgpack. Values nested in maps and
+// arrays keep the smallest type they were encoded with, such as int8.
+func toUint64(v interface{}) uint64 {
+	n := reflect.ValueOf(v)
+
+	switch n.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(n.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return n.Uint()
+	case reflect.Float32, reflect.Float64:
+		return uint64(n.Float())
+	}
+
+	return 0
+}
+
+// memberRoles returns the roles of a member in the order they apply, from
+// the lowest to the highest ranked. A lower rank takes priority.
+func memberRoles(guild *Guild, member *GuildMember) (roleIDs []string) {
+	for _, roleID := range member.Roles {
+		if _, ok := guild.Roles[roleID]; ok {
+			roleIDs = append(roleIDs, roleID)
+		}
+	}
+
+	sort.SliceStable(roleIDs, func(i, j int) bool {
+		return guild.Roles[roleIDs[i]].Rank > guild.Roles[roleIDs[j]].Rank
+	})
+
+	return roleIDs
+}
+
+// ServerPermissions computes the permissions a member has in a server.
+func ServerPermissions(guild *Guild, member *GuildMember) (perms ServerPermission) {
+	if member.ID != nil && member.ID.User == guild.Owner {
+		return ServerPermissionAll
+	}
+
+	perms = guild.DefaultPermissions.Server
+
+	for _, roleID := range memberRoles(guild, member) {
+		perms |= guild.Roles[roleID].Permissions.Server
+	}
+
+	return perms
+}
+
+// ChannelPermissions computes the permissions a member has in a channel of
+// a server. The server owner has every permission. Everyone else starts
+// from the server defaults combined with the permissions each of their
+// roles grants. The channel's default override is applied to that, then
+// the channel's override for each role, from the lowest to the highest
+// ranked. Without permission to view the channel no other permission
+// applies.
+func ChannelPermissions(guild *Guild, channel *Channel, member *GuildMember) (perms ChannelPermission) {
+	if member.ID != nil && member.ID.User == guild.Owner {
+		return ChannelPermissionAll
+	}
+
+	defaults, overrides := channel.permissionOverrides()
+	roleIDs := memberRoles(guild, member)
+
+	perms = guild.DefaultPermissions.Channel
+	for _, roleID := range roleIDs {
+		perms |= guild.Roles[roleID].Permissions.Channel
+	}
+
+	perms = defaults.apply(perms)
+
+	for _, roleID := range roleIDs {
+		perms = overrides[roleID].apply(perms)
+	}
+
+	if !perms.Has(ChannelPermissionView) {
+		return 0
+	}
+
+	return perms
+}
+
+// Permissions returns the permissions a user has in a channel, using the
+// cache and fetching the channel, server and member if they are missing.
+func (rb *RevoltBot) Permissions(channelID string, userID string) (perms ChannelPermission, err error) {
+	channel, ok := rb.Channel(channelID)
+	if !ok {
+		channel, err = rb.FetchChannel(channelID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	guildID := channel.GuildID()
+	if guildID == "" {
+		return channelPermissions(channel, userID), nil
+	}
+
+	guild, err := rb.guild(guildID)
+	if err != nil {
+		return 0, err
+	}
+
+	member, ok := rb.Member(guildID, userID)
+	if !ok {
+		member, err = rb.FetchMember(guildID, userID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return ChannelPermissions(guild, channel, member), nil
+}
+
+// channelPermissions returns the permissions a user has in a channel outside
+// of a server. Users have every permission there, except in groups which
+// may restrict what recipients other than the owner can do.
+func channelPermissions(channel *Channel, userID string) ChannelPermission {
+	if group := channel.Group; group != nil && group.Owner != userID && group.Permissions != nil {
+		return *group.Permissions
+	}
+
+	return ChannelPermissionAll
+}
@@ -0,0 +1,148 @@
+package revolt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPermissionSet(t *testing.T) {
+	const frame = `{"type": "ServerRoleUpdate", "id": "guild", "role_id": "role", "data": {"permissions": [5, 3]}}`
+
+	want := PermissionSet{ServerPermissionView | ServerPermissionManageChannels, ChannelPermissionView | ChannelPermissionSendMessage}
+
+	for _, c := range codecs {
+		o := decodeFrame(t, c, frame).(ServerRoleUpdate)
+		if o.Role.Permissions != want {
+			t.Errorf("%s: permissions = %+v, want %+v", c.Format(), o.Role.Permissions, want)
+		}
+
+		data, err := c.Marshal(o.Role)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		role := &GuildRole{}
+		if err := c.Unmarshal(data, role); err != nil || !reflect.DeepEqual(role, o.Role) {
+			t.Errorf("%s: round trip gave %+v, %v", c.Format(), role, err)
+		}
+	}
+
+	data, _ := json.Marshal(want)
+	if string(data) != "[5,3]" {
+		t.Errorf("encoded as %s, want [5,3]", data)
+	}
+}
+
+func TestPermissionOverride(t *testing.T) {
+	tests := []struct {
+		name     string
+		override string
+		want     PermissionOverride
+	}{
+		{
+			name:     "object",
+			override: `{"a": 3, "d": 128}`,
+			want:     PermissionOverride{Allow: ChannelPermissionView | ChannelPermissionSendMessage, Deny: ChannelPermissionUploadFiles},
+		},
+		{
+			name:     "legacy number",
+			override: `3`,
+			want:     newLegacyOverride(ChannelPermissionView | ChannelPermissionSendMessage),
+		},
+	}
+
+	for _, test := range tests {
+		frame := `{"type": "ChannelCreate", "_id": "channel", "channel_type": "TextChannel", "server": "guild", "default_permissions": ` + test.override + `}`
+
+		for _, c := range codecs {
+			t.Run(test.name+"/"+c.Format(), func(t *testing.T) {
+				o := decodeFrame(t, c, frame).(ChannelCreate)
+				if got := o.TextChannel.DefaultPermissions; got == nil || *got != test.want {
+					t.Errorf("override = %+v, want %+v", got, test.want)
+				}
+			})
+		}
+	}
+}
+
+func TestChannelPermissions(t *testing.T) {
+	guild := &Guild{
+		ID:                 "guild",
+		Owner:              "owner",
+		DefaultPermissions: PermissionSet{Channel: ChannelPermissionView},
+		Roles: map[string]*GuildRole{
+			"low":  {Permissions: PermissionSet{Channel: ChannelPermissionSendMessage}, Rank: 2},
+			"high": {Permissions: PermissionSet{Channel: ChannelPermissionUploadFiles}, Rank: 1},
+		},
+	}
+
+	channel := func(defaults *PermissionOverride, roles map[string]*PermissionOverride) *Channel {
+		return &Channel{
+			ID:          "channel",
+			ChannelType: ChannelTypeText,
+			TextChannel: &TextChannel{Server: "guild", DefaultPermissions: defaults, RolePermissions: roles},
+		}
+	}
+
+	member := func(user string, roles ...string) *GuildMember {
+		return &GuildMember{ID: &GuildMemberIDs{Server: "guild", User: user}, Roles: roles}
+	}
+
+	tests := []struct {
+		name    string
+		channel *Channel
+		member  *GuildMember
+		want    ChannelPermission
+	}{
+		{
+			name:    "owner",
+			channel: channel(&PermissionOverride{Deny: ChannelPermissionAll}, nil),
+			member:  member("owner"),
+			want:    ChannelPermissionAll,
+		},
+		{
+			name:    "defaults",
+			channel: channel(nil, nil),
+			member:  member("user"),
+			want:    ChannelPermissionView,
+		},
+		{
+			name:    "roles",
+			channel: channel(nil, nil),
+			member:  member("user", "low", "high", "unknown"),
+			want:    ChannelPermissionView | ChannelPermissionSendMessage | ChannelPermissionUploadFiles,
+		},
+		{
+			name:    "higher role override wins",
+			channel: channel(nil, map[string]*PermissionOverride{"low": {Deny: ChannelPermissionUploadFiles}, "high": {Allow: ChannelPermissionUploadFiles}}),
+			member:  member("user", "high", "low"),
+			want:    ChannelPermissionView | ChannelPermissionSendMessage | ChannelPermissionUploadFiles,
+		},
+		{
+			name:    "channel default deny beats role grant",
+			channel: channel(&PermissionOverride{Deny: ChannelPermissionSendMessage}, nil),
+			member:  member("user", "low"),
+			want:    ChannelPermissionView,
+		},
+		{
+			name:    "role override beats channel default deny",
+			channel: channel(&PermissionOverride{Deny: ChannelPermissionSendMessage}, map[string]*PermissionOverride{"high": {Allow: ChannelPermissionSendMessage}}),
+			member:  member("user", "high"),
+			want:    ChannelPermissionView | ChannelPermissionSendMessage | ChannelPermissionUploadFiles,
+		},
+		{
+			name:    "hidden channel",
+			channel: channel(&PermissionOverride{Deny: ChannelPermissionView}, nil),
+			member:  member("user", "low"),
+			want:    0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ChannelPermissions(guild, test.channel, test.member); got != test.want {
+				t.Errorf("ChannelPermissions() = %b, want %b", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package revolt
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Number of times a request is retried after receiving a 429.
+const maxRateLimitRetries = 5
+
+// rateLimiter queues requests per bucket using the X-RateLimit headers sent
+// by the API. Routes share a bucket once the API has told us they do.
+// Routes are keyed by their template, see routeTemplate, so the maps do not
+// grow with every object a request is made about.
+type rateLimiter struct {
+	mu      sync.Mutex
+	routes  map[string]string
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	// Held for the duration of a request, so requests to the same bucket
+	// are sent one after another.
+	mu sync.Mutex
+
+	remaining int
+	reset     time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		routes:  make(map[string]string),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// getBucket returns the bucket for a route, creating a temporary bucket
+// named after the route until the API tells us which one it belongs to.
+func (rl *rateLimiter) getBucket(route string) *bucket {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	id, ok := rl.routes[route]
+	if !ok {
+		id = route
+	}
+
+	b, ok := rl.buckets[id]
+	if !ok {
+		b = &bucket{remaining: 1}
+		rl.buckets[id] = b
+	}
+
+	return b
+}
+
+// setBucket records which bucket a route belongs to.
+func (rl *rateLimiter) setBucket(route, id string, b *bucket) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if rl.routes[route] == id {
+		return
+	}
+
+	rl.routes[route] = id
+	if _, ok := rl.buckets[id]; !ok {
+		rl.buckets[id] = b
+	}
+}
+
+// do sends a request once its bucket has requests remaining, retrying it if
+// it was rate limited regardless.
+func (rl *rateLimiter) do(ctx context.Context, client *http.Client, req *http.Request) (resp *http.Response, err error) {
+	route := routeTemplate(req)
+
+	b := rl.getBucket(route)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for retry := 0; ; retry++ {
+		if b.remaining <= 0 {
+			err = sleep(ctx, time.Until(b.reset))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if retry > 0 && req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err = client.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		b.update(resp.Header)
+
+		if id := resp.Header.Get("X-RateLimit-Bucket"); id != "" {
+			rl.setBucket(route, id, b)
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests || retry >= maxRateLimitRetries {
+			return resp, nil
+		}
+
+		// Fall back to the retry_after in the body if the headers did not
+		// say when we can try again.
+		if !b.reset.After(time.Now()) {
+			body, _ := ioutil.ReadAll(resp.Body)
+
+			retryAfter := json.Get(body, "retry_after").ToFloat64()
+			if retryAfter <= 0 {
+				retryAfter = 1000
+			}
+
+			b.reset = time.Now().Add(time.Duration(retryAfter * float64(time.Millisecond)))
+		}
+
+		resp.Body.Close()
+
+		println("Rate limited on " + route + ", retrying in " + time.Until(b.reset).String())
+
+		b.remaining = 0
+	}
+}
+
+// idSegmentRegex matches a path segment that is an ID.
+var idSegmentRegex = regexp.MustCompile(`^` + idPattern + `$`)
+
+// routeTemplate returns the route of a request with the IDs in its path
+// replaced by :id, so requests about different objects of the same kind
+// share a temporary bucket.
+func routeTemplate(req *http.Request) string {
+	segments := strings.Split(req.URL.Path, "/")
+	for i, segment := range segments {
+		if idSegmentRegex.MatchString(segment) {
+			segments[i] = ":id"
+		}
+	}
+
+	return req.Method + " " + req.URL.Host + strings.Join(segments, "/")
+}
+
+// update reads the remaining requests and time until the bucket resets.
+func (b *bucket) update(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		b.remaining = 1
+
+		return
+	}
+
+	resetAfter, err := strconv.ParseFloat(header.Get("X-RateLimit-Reset-After"), 64)
+	if err != nil {
+		resetAfter = 0
+	}
+
+	b.remaining = remaining
+	b.reset = time.Now().Add(time.Duration(resetAfter * float64(time.Millisecond)))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
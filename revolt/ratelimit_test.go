@@ -0,0 +1,183 @@
+package revolt
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestBot(t *testing.T, handler http.Handler) *RevoltBot {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return NewRevoltBot("token", WithBaseURL(srv.URL), WithAutumnURL(srv.URL))
+}
+
+func TestRateLimitBucketQueuing(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	var mu sync.Mutex
+
+	var sent []time.Time
+
+	rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		if n > atomic.LoadInt32(&maxInFlight) {
+			atomic.StoreInt32(&maxInFlight, n)
+		}
+
+		mu.Lock()
+		sent = append(sent, time.Now())
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		w.Header().Set("X-RateLimit-Bucket", "bucket")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "100")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := rb.request(http.MethodGet, "/users/@me", nil, nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&maxInFlight); n != 1 {
+		t.Errorf("%d requests to the same bucket were sent at once", n)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(sent) != 3 {
+		t.Fatalf("server received %d requests, want 3", len(sent))
+	}
+
+	// Every response said the bucket is empty for 100ms.
+	for i := 1; i < len(sent); i++ {
+		if d := sent[i].Sub(sent[i-1]); d < 90*time.Millisecond {
+			t.Errorf("request %d sent %v after the previous one, want the bucket to reset first", i, d)
+		}
+	}
+}
+
+func TestRateLimitRetry(t *testing.T) {
+	var mu sync.Mutex
+
+	var bodies []string
+
+	rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		first := len(bodies) == 1
+		mu.Unlock()
+
+		if first {
+			// No headers, so the client must use retry_after.
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"retry_after":20}`))
+
+			return
+		}
+
+		w.Write([]byte(`{"_id":"user"}`))
+	}))
+
+	var user User
+
+	err := rb.request(http.MethodPost, "/users/@me", map[string]string{"name": "value"}, &user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(bodies) != 2 {
+		t.Fatalf("server received %d requests, want 2", len(bodies))
+	}
+
+	if user.ID != "user" {
+		t.Errorf("decoded %+v from the retried response", user)
+	}
+
+	if strings.TrimSpace(bodies[0]) != `{"name":"value"}` || bodies[1] != bodies[0] {
+		t.Errorf("retry sent body %q, first request sent %q", bodies[1], bodies[0])
+	}
+}
+
+func TestRateLimitRetryLimit(t *testing.T) {
+	var requests int32
+
+	rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+
+	err := rb.request(http.MethodGet, "/users/@me", nil, nil)
+
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("request() error = %v, want a 429 *APIError", err)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != maxRateLimitRetries+1 {
+		t.Errorf("server received %d requests, want %d", n, maxRateLimitRetries+1)
+	}
+}
+
+func TestRateLimitRouteTemplate(t *testing.T) {
+	rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for _, id := range []string{"01G8ZY4AAAAAAAAAAAAAAAAAAA", "01G8ZY4BBBBBBBBBBBBBBBBBBB", "01G8ZY4CCCCCCCCCCCCCCCCCCC"} {
+		if err := rb.request(http.MethodDelete, "/channels/"+id+"/messages/"+id, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := rb.request(http.MethodGet, "/users/@me", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	rb.rateLimiter.mu.Lock()
+	defer rb.rateLimiter.mu.Unlock()
+
+	var routes []string
+	for route := range rb.rateLimiter.buckets {
+		routes = append(routes, route[strings.Index(route, "/"):])
+	}
+
+	sort.Strings(routes)
+
+	if want := []string{"/channels/:id/messages/:id", "/users/@me"}; !reflect.DeepEqual(routes, want) {
+		t.Errorf("buckets = %v, want %v", routes, want)
+	}
+}
@@ -0,0 +1,217 @@
+package revolt
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Post sends a POST request to the API. If the response has an error
+// status an *APIError is returned, otherwise the caller must close the
+// response body.
+func (rb *RevoltBot) Post(path string, data interface{}) (resp *http.Response, err error) {
+	return rb.send(http.MethodPost, path, data)
+}
+
+// Get sends a GET request to the API. If the response has an error status
+// an *APIError is returned, otherwise the caller must close the response
+// body.
+func (rb *RevoltBot) Get(path string) (resp *http.Response, err error) {
+	return rb.send(http.MethodGet, path, nil)
+}
+
+// request sends a request to the API and decodes the response into out,
+// if it is not nil.
+func (rb *RevoltBot) request(method, path string, data, out interface{}) (err error) {
+	resp, err := rb.send(method, path, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		_, err = io.Copy(ioutil.Discard, resp.Body)
+
+		return err
+	}
+
+	res, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(res, out)
+}
+
+// send encodes data as the JSON body of a request to the API.
+func (rb *RevoltBot) send(method, path string, data interface{}) (resp *http.Response, err error) {
+	var body io.Reader
+
+	if data != nil {
+		buf := bytes.NewBuffer(nil)
+
+		err = json.NewEncoder(buf).Encode(data)
+		if err != nil {
+			return nil, err
+		}
+
+		body = buf
+	}
+
+	req, err := http.NewRequest(method, rb.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return rb.do(req)
+}
+
+// do authenticates and sends a request. Responses with an error status are
+// closed and returned as an *APIError.
+func (rb *RevoltBot) do(req *http.Request) (resp *http.Response, err error) {
+	req.Header.Set("x-bot-token", rb.Token)
+	req.Header.Set("User-Agent", rb.userAgent)
+
+	resp, err = rb.rateLimiter.do(rb.ctx, rb.httpClient, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+
+		return nil, newAPIError(resp)
+	}
+
+	return resp, nil
+}
+
+func (rb *RevoltBot) UploadFile(fileName string, fileContent []byte) (autumnID string, err error) {
+	autumnURL := rb.AutumnURL()
+	if autumnURL == "" {
+		return "", ErrNoAutumnURL
+	}
+
+	b := new(bytes.Buffer)
+	w := multipart.NewWriter(b)
+	part, _ := w.CreateFormFile("file", fileName)
+	part.Write(fileContent)
+	w.Close()
+
+	req, err := http.NewRequest("POST", autumnURL+"/attachments", b)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	res, err := rb.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	dat, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	autumnID = json.Get(dat, "id").ToString()
+
+	return autumnID, nil
+}
+
+func (rb *RevoltBot) FetchUser(userID string) (user *User, err error) {
+	err = rb.request(http.MethodGet, "/users/"+userID, nil, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	rb.State.SetUser(user)
+
+	return user, nil
+}
+
+func (rb *RevoltBot) SendMessage(channelID string, messageRequest *MessageRequest) (message *Message, err error) {
+	if messageRequest.Nonce == "" {
+		messageRequest.Nonce = strconv.FormatInt(time.Now().Unix(), 10)
+		println("Dont forget to add a nonce")
+	}
+
+	err = rb.request(http.MethodPost, "/channels/"+channelID+"/messages", messageRequest, &message)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// FetchNodeInfo returns the configuration of the Revolt instance, which is
+// served from the root of the API.
+func (rb *RevoltBot) FetchNodeInfo() (nodeInfo *NodeInfo, err error) {
+	err = rb.request(http.MethodGet, "/", nil, &nodeInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return nodeInfo, nil
+}
+
+// discoverURLs sets the websocket and Autumn URLs that were not passed as
+// options from the node info. Only the official instance falls back to its
+// well-known URLs, so the token of a self-hosted instance is never sent to
+// the official gateway.
+func (rb *RevoltBot) discoverURLs() (err error) {
+	official := rb.baseURL == RevoltHTTPBase
+
+	nodeInfo, err := rb.FetchNodeInfo()
+	if err != nil {
+		if !official {
+			return err
+		}
+
+		println("Failed to fetch node info: " + err.Error())
+
+		nodeInfo = &NodeInfo{}
+	}
+
+	if rb.wsURL == "" {
+		switch {
+		case nodeInfo.WS != "":
+			rb.wsURL = nodeInfo.WS
+		case official:
+			rb.wsURL = RevoltWS
+		default:
+			return ErrNoWebsocketURL
+		}
+	}
+
+	if rb.autumnURL == "" {
+		if nodeInfo.Features != nil && nodeInfo.Features.Autumn != nil && nodeInfo.Features.Autumn.Enabled {
+			rb.autumnURL = strings.TrimSuffix(nodeInfo.Features.Autumn.URL, "/")
+		} else if official {
+			rb.autumnURL = AutumnHTTPBase
+		}
+	}
+
+	return nil
+}
+
+// AutumnURL returns the base URL of the file server. For instances other
+// than the official one it is empty until Start has discovered it, or if the
+// instance has no file server.
+func (rb *RevoltBot) AutumnURL() string {
+	if rb.autumnURL == "" && rb.baseURL == RevoltHTTPBase {
+		return AutumnHTTPBase
+	}
+
+	return rb.autumnURL
+}
@@ -0,0 +1,138 @@
+package revolt
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc lets a function stand in for the API, including the
+// official instance.
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func respond(status int, body string) roundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+}
+
+func TestDiscoverURLs(t *testing.T) {
+	const selfHosted = "https://revolt.example.com"
+
+	tests := []struct {
+		name       string
+		baseURL    string
+		api        roundTripFunc
+		wantErr    error
+		wantWS     string
+		wantAutumn string
+	}{
+		{
+			name:       "self-hosted",
+			baseURL:    selfHosted,
+			api:        respond(http.StatusOK, `{"ws": "wss://ws.example.com", "features": {"autumn": {"enabled": true, "url": "https://autumn.example.com/"}}}`),
+			wantWS:     "wss://ws.example.com",
+			wantAutumn: "https://autumn.example.com",
+		},
+		{
+			name:    "self-hosted without file server",
+			baseURL: selfHosted,
+			api:     respond(http.StatusOK, `{"ws": "wss://ws.example.com", "features": {"autumn": {"enabled": false}}}`),
+			wantWS:  "wss://ws.example.com",
+		},
+		{
+			name:    "self-hosted without websocket",
+			baseURL: selfHosted,
+			api:     respond(http.StatusOK, `{}`),
+			wantErr: ErrNoWebsocketURL,
+		},
+		{
+			name:    "self-hosted unavailable",
+			baseURL: selfHosted,
+			api:     respond(http.StatusInternalServerError, ``),
+			wantErr: &APIError{},
+		},
+		{
+			name:       "official unavailable",
+			baseURL:    RevoltHTTPBase,
+			api:        respond(http.StatusInternalServerError, ``),
+			wantWS:     RevoltWS,
+			wantAutumn: AutumnHTTPBase,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rb := NewRevoltBot("token", WithBaseURL(test.baseURL), WithHTTPClient(&http.Client{Transport: test.api}))
+
+			err := rb.discoverURLs()
+
+			var apiErr *APIError
+
+			switch want := test.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatal(err)
+				}
+			case *APIError:
+				if !errors.As(err, &apiErr) {
+					t.Fatalf("discoverURLs() = %v, want an *APIError", err)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Fatalf("discoverURLs() = %v, want %v", err, want)
+				}
+			}
+
+			if rb.wsURL != test.wantWS || rb.AutumnURL() != test.wantAutumn {
+				t.Errorf("websocket URL = %q, Autumn URL = %q, want %q and %q", rb.wsURL, rb.AutumnURL(), test.wantWS, test.wantAutumn)
+			}
+		})
+	}
+}
+
+func TestStartSelfHostedUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	rb := NewRevoltBot("token", WithBaseURL(srv.URL))
+
+	var apiErr *APIError
+	if err := rb.Start(); !errors.As(err, &apiErr) {
+		t.Errorf("Start() = %v, want the node info error", err)
+	}
+
+	if rb.wsURL != "" {
+		t.Errorf("websocket URL = %q, want none", rb.wsURL)
+	}
+}
+
+func TestAutumnURLBeforeStart(t *testing.T) {
+	if got := NewRevoltBot("").AutumnURL(); got != AutumnHTTPBase {
+		t.Errorf("official AutumnURL() = %q, want %q", got, AutumnHTTPBase)
+	}
+
+	rb := NewRevoltBot("", WithBaseURL("https://revolt.example.com"))
+
+	if got := rb.AutumnURL(); got != "" {
+		t.Errorf("self-hosted AutumnURL() = %q, want none before discovery", got)
+	}
+
+	if _, err := rb.UploadFile("file.txt", []byte("file")); !errors.Is(err, ErrNoAutumnURL) {
+		t.Errorf("UploadFile() = %v, want ErrNoAutumnURL", err)
+	}
+}
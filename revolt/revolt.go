@@ -0,0 +1,485 @@
+package revolt
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/savsgio/gotils"
+	"nhooyr.io/websocket"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+const RevoltWS = "wss://ws.revolt.chat"
+const RevoltHTTPBase = "https://api.revolt.chat"
+const AutumnHTTPBase = "https://autumn.revolt.chat"
+const DefaultUserAgent = "WelcomerRevolt (https://github.com/WelcomerTeam/Revolt)"
+
+const (
+	DefaultReconnectMinDelay = time.Second
+	DefaultReconnectMaxDelay = time.Minute * 2
+
+	DefaultHeartbeatInterval = time.Second * 20
+	DefaultMaxMissedPongs    = 3
+
+	DefaultShutdownTimeout = time.Second * 10
+)
+
+type RevoltBot struct {
+	// Context of REST calls, cancelled by Shutdown once the handlers have
+	// finished.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// Context of the gateway connection, cancelled by Shutdown before it
+	// waits for the handlers.
+	gatewayCtx    context.Context
+	gatewayCancel context.CancelFunc
+
+	Token string
+
+	// State caches the users, servers, channels and members received from
+	// the gateway and the API.
+	State Cache
+
+	// Kinds of state stored in State, set with WithCacheFlags.
+	cacheFlags CacheFlags
+
+	stateMu sync.Mutex
+
+	// ID of the bot's own user, taken from Ready.
+	selfID string
+
+	// Serialises AddMemberRole and RemoveMemberRole.
+	memberRolesMu sync.Mutex
+
+	// Snapshot loaded on start and saved on shutdown, if set.
+	snapshotPath string
+	snapshot     *Snapshot
+
+	messages *messageCache
+
+	// Use MessagePack instead of JSON for the gateway connection. This must
+	// be set before calling Start.
+	Msgpack bool
+
+	codec codec
+
+	// Bounds of the exponential backoff used when redialing the gateway.
+	ReconnectMinDelay time.Duration
+	ReconnectMaxDelay time.Duration
+
+	// How often a Ping is sent and how many may go unanswered before the
+	// connection is considered dead and is reconnected.
+	HeartbeatInterval time.Duration
+	MaxMissedPongs    int
+
+	heartbeatMu  sync.Mutex
+	pendingPings map[int64]time.Time
+	latencies    []time.Duration
+
+	handlers handlerRegistry
+
+	rateLimiter *rateLimiter
+
+	httpClient *http.Client
+	userAgent  string
+
+	// The websocket and Autumn URLs are discovered from the API when Start
+	// is called unless they were set with options.
+	baseURL   string
+	wsURL     string
+	autumnURL string
+
+	wsMu   sync.RWMutex
+	wsConn *websocket.Conn
+
+	dispatchMu       sync.Mutex
+	dispatchWg       sync.WaitGroup
+	dispatchInFlight map[string]int
+	closed           bool
+}
+
+func NewRevoltBot(token string, opts ...Option) (rb *RevoltBot) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), "revolt", "revolt-bot"))
+
+	gatewayCtx, gatewayCancel := context.WithCancel(ctx)
+
+	rb = &RevoltBot{
+		ctx:    ctx,
+		cancel: cancel,
+
+		gatewayCtx:    gatewayCtx,
+		gatewayCancel: gatewayCancel,
+		Token:         token,
+
+		State:      NewMapCache(),
+		cacheFlags: CacheAll,
+
+		messages: newMessageCache(DefaultMessageCacheSize),
+
+		ReconnectMinDelay: DefaultReconnectMinDelay,
+		ReconnectMaxDelay: DefaultReconnectMaxDelay,
+
+		HeartbeatInterval: DefaultHeartbeatInterval,
+		MaxMissedPongs:    DefaultMaxMissedPongs,
+
+		codec: jsonCodec{},
+
+		rateLimiter: newRateLimiter(),
+
+		httpClient: http.DefaultClient,
+		userAgent:  DefaultUserAgent,
+
+		baseURL: RevoltHTTPBase,
+
+		dispatchInFlight: make(map[string]int),
+	}
+
+	for _, opt := range opts {
+		opt(rb)
+	}
+
+	if rb.cacheFlags != CacheAll {
+		rb.State = NewFilteredCache(rb.State, rb.cacheFlags)
+	}
+
+	return rb
+}
+
+func (rb *RevoltBot) Start() (err error) {
+	var attempt int
+
+	if rb.Msgpack {
+		rb.codec = msgpackCodec{}
+	} else {
+		rb.codec = jsonCodec{}
+	}
+
+	if rb.wsURL == "" || rb.autumnURL == "" {
+		err = rb.discoverURLs()
+		if err != nil {
+			return err
+		}
+	}
+
+	if rb.snapshotPath != "" {
+		err = rb.LoadSnapshot(rb.snapshotPath)
+		if err != nil && !os.IsNotExist(err) {
+			println("Failed to load snapshot: " + err.Error())
+		}
+	}
+
+	for {
+		var ready bool
+
+		ready, err = rb.connect()
+		if ready {
+			attempt = 0
+		}
+
+		// The context is only cancelled by Shutdown.
+		if rb.gatewayCtx.Err() != nil {
+			return nil
+		}
+
+		var gatewayError *GatewayError
+		if errors.As(err, &gatewayError) && gatewayError.Fatal() {
+			return err
+		}
+
+		attempt++
+		delay := rb.reconnectDelay(attempt)
+
+		println("Connection lost (" + err.Error() + "), reconnecting in " + delay.String())
+
+		select {
+		case <-time.After(delay):
+		case <-rb.gatewayCtx.Done():
+			return nil
+		}
+	}
+}
+
+// connect dials the gateway, authenticates and reads from it until the
+// connection fails. ready reports if a Ready was received on the connection.
+func (rb *RevoltBot) connect() (ready bool, err error) {
+	u, err := url.Parse(rb.wsURL)
+	if err != nil {
+		return false, err
+	}
+
+	query := u.Query()
+	query.Set("format", rb.codec.Format())
+	u.RawQuery = query.Encode()
+
+	conn, _, err := websocket.Dial(rb.gatewayCtx, u.String(), &websocket.DialOptions{
+		HTTPClient: rb.httpClient,
+		HTTPHeader: http.Header{"User-Agent": []string{rb.userAgent}},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// Ready holds every server the bot is in, which is far more than the
+	// default limit of 32KiB. This version of the library cannot disable the
+	// limit, and the limit is incremented by one internally.
+	conn.SetReadLimit(math.MaxInt64 - 1)
+
+	ctx, cancel := context.WithCancel(rb.gatewayCtx)
+	defer cancel()
+
+	rb.wsMu.Lock()
+	rb.wsConn = conn
+	rb.wsMu.Unlock()
+
+	defer func() {
+		rb.wsMu.Lock()
+		rb.wsConn = nil
+		rb.wsMu.Unlock()
+
+		conn.Close(websocket.StatusGoingAway, "")
+	}()
+
+	println("CONNECTED TO " + rb.wsURL)
+
+	err = rb.SendEvent(Authenticate{
+		SentBase: SentBase{EventTypeAuthenticate},
+		Token:    &rb.Token,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	rb.resetHeartbeat()
+
+	go rb.Heartbeat(ctx, conn)
+
+	for {
+		_, buf, err := conn.Read(ctx)
+		if err != nil {
+			return ready, err
+		}
+
+		mType, err := eventType(rb.codec, buf)
+		if err != nil {
+			println("Failed to decode frame: " + err.Error())
+
+			continue
+		}
+
+		// The state is updated here so events are applied in the order
+		// they were received, only the handlers run concurrently.
+		event, err := rb.applyEvent(mType, buf)
+		if err != nil {
+			println("Failed to decode " + mType + ": " + err.Error())
+
+			continue
+		}
+
+		if event == nil {
+			continue
+		}
+
+		switch o := event.(type) {
+		case Ready:
+			ready = true
+		case Error:
+			// Errors are dispatched to the handlers as usual, but fatal
+			// ones also stop Start.
+			if gatewayError := o.Err(); gatewayError.Fatal() {
+				rb.handleEvent(mType, event)
+
+				return ready, gatewayError
+			}
+		}
+
+		rb.dispatch(mType, event)
+	}
+}
+
+// dispatch calls the handlers of an event in a new goroutine which
+// Shutdown waits for.
+func (rb *RevoltBot) dispatch(messageType string, event interface{}) {
+	rb.dispatchMu.Lock()
+	defer rb.dispatchMu.Unlock()
+
+	if rb.closed {
+		return
+	}
+
+	rb.dispatchWg.Add(1)
+	rb.dispatchInFlight[messageType]++
+
+	go func() {
+		defer func() {
+			rb.dispatchMu.Lock()
+			rb.dispatchInFlight[messageType]--
+			if rb.dispatchInFlight[messageType] == 0 {
+				delete(rb.dispatchInFlight, messageType)
+			}
+			rb.dispatchMu.Unlock()
+
+			rb.dispatchWg.Done()
+		}()
+
+		rb.handleEvent(messageType, event)
+	}()
+}
+
+// Shutdown closes the gateway connection with a normal close frame, stops
+// Start and waits for the events currently being dispatched to finish. If
+// ctx is done before they have, a *ShutdownError reporting the events that
+// were dropped is returned.
+func (rb *RevoltBot) Shutdown(ctx context.Context) (err error) {
+	rb.dispatchMu.Lock()
+	rb.closed = true
+	rb.dispatchMu.Unlock()
+
+	rb.wsMu.RLock()
+	conn := rb.wsConn
+	rb.wsMu.RUnlock()
+
+	if conn != nil {
+		conn.Close(websocket.StatusNormalClosure, "")
+	}
+
+	rb.gatewayCancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		rb.dispatchWg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		rb.dispatchMu.Lock()
+		dropped := make(map[string]int, len(rb.dispatchInFlight))
+		for t, n := range rb.dispatchInFlight {
+			dropped[t] = n
+		}
+		rb.dispatchMu.Unlock()
+
+		err = &ShutdownError{Dropped: dropped, err: ctx.Err()}
+	}
+
+	rb.cancel()
+
+	if rb.snapshotPath != "" {
+		if snapshotErr := rb.SaveSnapshot(rb.snapshotPath); snapshotErr != nil && err == nil {
+			err = snapshotErr
+		}
+	}
+
+	return err
+}
+
+// Close shuts the bot down, waiting up to DefaultShutdownTimeout for
+// events to finish dispatching.
+func (rb *RevoltBot) Close() (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultShutdownTimeout)
+	defer cancel()
+
+	return rb.Shutdown(ctx)
+}
+
+// reconnectDelay returns the jittered exponential backoff to wait before
+// the specified reconnection attempt.
+func (rb *RevoltBot) reconnectDelay(attempt int) time.Duration {
+	delay := rb.ReconnectMaxDelay
+
+	if attempt < 32 {
+		if d := rb.ReconnectMinDelay << uint(attempt-1); d > 0 && d < delay {
+			delay = d
+		}
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func (rb *RevoltBot) SendEvent(data interface{}) (err error) {
+	val, err := rb.codec.Marshal(data)
+	if err != nil {
+		println(err.Error())
+
+		return err
+	}
+
+	// Authenticate carries the token, so it is never logged.
+	switch data.(type) {
+	case Authenticate, *Authenticate:
+	default:
+		if rb.codec.MessageType() == websocket.MessageText {
+			println("<-", gotils.B2S(val))
+		}
+	}
+
+	rb.wsMu.RLock()
+	conn := rb.wsConn
+	rb.wsMu.RUnlock()
+
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	return conn.Write(rb.gatewayCtx, rb.codec.MessageType(), val)
+}
+
+// OnDispatch decodes a frame received from the gateway, applies it to the
+// state and calls the handlers of the event.
+func (rb *RevoltBot) OnDispatch(messageType string, data []byte) (err error) {
+	event, err := rb.applyEvent(messageType, data)
+	if err != nil || event == nil {
+		return err
+	}
+
+	rb.handleEvent(messageType, event)
+
+	return nil
+}
+
+// applyEvent decodes a frame and applies it to the state. It returns a nil
+// event for frames of an unknown type.
+func (rb *RevoltBot) applyEvent(messageType string, data []byte) (event interface{}, err error) {
+	if rb.codec.MessageType() == websocket.MessageText {
+		println("-> ", gotils.B2S(data))
+	}
+
+	decode, ok := eventDecoders[messageType]
+	if !ok {
+		println(messageType + " not implemented")
+
+		return nil, nil
+	}
+
+	event, err = decode(rb.codec, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return rb.updateState(event, data), nil
+}
+
+// handleEvent calls the handlers of an event already applied to the state.
+func (rb *RevoltBot) handleEvent(messageType string, event interface{}) {
+	rb.handle(messageType, event)
+
+	if o, ok := event.(Ready); ok {
+		rb.reconcileSnapshot(o)
+	}
+}
@@ -0,0 +1,193 @@
+package revolt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+// newFakeGateway starts a websocket server standing in for the gateway and
+// returns a bot connecting to it. serve is called with the number of the
+// connection, starting at 1, once the bot has sent Authenticate. The
+// connection is closed when serve returns.
+func newFakeGateway(t *testing.T, serve func(ctx context.Context, conn *websocket.Conn, n int)) (rb *RevoltBot, tokens func() []string) {
+	var mu sync.Mutex
+
+	var received []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+		defer conn.Close(websocket.StatusInternalError, "dropped")
+
+		_, buf, err := conn.Read(r.Context())
+		if err != nil {
+			return
+		}
+
+		o := Authenticate{}
+		if err := json.Unmarshal(buf, &o); err != nil || o.Type != EventTypeAuthenticate || o.Token == nil {
+			t.Errorf("first frame %s is not Authenticate", buf)
+
+			return
+		}
+
+		mu.Lock()
+		received = append(received, *o.Token)
+		n := len(received)
+		mu.Unlock()
+
+		serve(r.Context(), conn, n)
+	}))
+	t.Cleanup(srv.Close)
+
+	rb = NewRevoltBot("token", WithWebsocketURL("ws"+strings.TrimPrefix(srv.URL, "http")), WithAutumnURL(srv.URL))
+	rb.ReconnectMinDelay = time.Millisecond
+	rb.ReconnectMaxDelay = 10 * time.Millisecond
+
+	return rb, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), received...)
+	}
+}
+
+// startBot runs Start in the background and returns a function which shuts
+// the bot down and returns the error of Start.
+func startBot(t *testing.T, rb *RevoltBot) (stop func() error) {
+	errc := make(chan error, 1)
+
+	go func() {
+		errc <- rb.Start()
+	}()
+
+	// Stop the bot if the test fails before calling stop.
+	t.Cleanup(func() {
+		rb.Shutdown(context.Background())
+	})
+
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		if err := rb.Shutdown(ctx); err != nil {
+			t.Errorf("Shutdown() = %v", err)
+		}
+
+		select {
+		case err := <-errc:
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("Start did not return after Shutdown")
+
+			return nil
+		}
+	}
+}
+
+// readUntilClosed reads and discards frames until the connection fails.
+func readUntilClosed(ctx context.Context, conn *websocket.Conn) {
+	for {
+		if _, _, err := conn.Read(ctx); err != nil {
+			return
+		}
+	}
+}
+
+const emptyReady = `{"type": "Ready", "users": [], "servers": [], "channels": [], "members": []}`
+
+func TestReconnect(t *testing.T) {
+	const connections = 3
+
+	rb, tokens := newFakeGateway(t, func(ctx context.Context, conn *websocket.Conn, n int) {
+		if err := conn.Write(ctx, websocket.MessageText, []byte(emptyReady)); err != nil {
+			return
+		}
+
+		// Drop every connection but the last.
+		if n < connections {
+			return
+		}
+
+		readUntilClosed(ctx, conn)
+	})
+
+	readies := make(chan struct{}, connections)
+
+	rb.AddHandler(func(rb *RevoltBot, o Ready) {
+		readies <- struct{}{}
+	})
+
+	stop := startBot(t, rb)
+
+	for i := 0; i < connections; i++ {
+		select {
+		case <-readies:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d Ready events, want %d", i, connections)
+		}
+	}
+
+	if err := stop(); err != nil {
+		t.Errorf("Start() = %v, want nil after Shutdown", err)
+	}
+
+	got := tokens()
+	if len(got) != connections {
+		t.Fatalf("bot authenticated %d times, want %d", len(got), connections)
+	}
+
+	for _, token := range got {
+		if token != "token" {
+			t.Errorf("bot authenticated with %q", token)
+		}
+	}
+}
+
+func TestShutdownKeepsRESTUntilHandlersFinish(t *testing.T) {
+	rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"_id":"user"}`))
+	}))
+
+	started := make(chan struct{})
+	result := make(chan error, 1)
+
+	rb.AddHandler(func(rb *RevoltBot, o Pong) {
+		close(started)
+
+		// Give Shutdown time to start waiting for the handler.
+		time.Sleep(50 * time.Millisecond)
+
+		_, err := rb.FetchUser("user")
+		result <- err
+	})
+
+	rb.dispatch(EventTypePong, Pong{})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := rb.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-result; err != nil {
+		t.Errorf("REST call from a draining handler failed: %v", err)
+	}
+
+	if rb.ctx.Err() == nil {
+		t.Error("REST context not cancelled after Shutdown")
+	}
+}
@@ -0,0 +1,230 @@
+package revolt
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Version of the snapshot format written by SaveSnapshot. Snapshots of
+// other versions are rejected by LoadSnapshot.
+const SnapshotVersion = 1
+
+var ErrSnapshotVersion = errors.New("unsupported snapshot version")
+
+// Snapshot is the cached state written to disk so it can be restored when
+// the bot restarts.
+type Snapshot struct {
+	Version   int   `json:"version"`
+	CreatedAt int64 `json:"created_at"`
+
+	Users    []*User        `json:"users"`
+	Guilds   []*Guild       `json:"servers"`
+	Channels []*Channel     `json:"channels"`
+	Members  []*GuildMember `json:"members"`
+}
+
+// Snapshot returns a snapshot of the cached state.
+func (rb *RevoltBot) Snapshot() (snapshot *Snapshot) {
+	snapshot = &Snapshot{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	rb.State.RangeUsers(func(u *User) bool {
+		snapshot.Users = append(snapshot.Users, u)
+
+		return true
+	})
+
+	rb.State.RangeGuilds(func(g *Guild) bool {
+		snapshot.Guilds = append(snapshot.Guilds, g)
+
+		return true
+	})
+
+	rb.State.RangeChannels(func(c *Channel) bool {
+		snapshot.Channels = append(snapshot.Channels, c)
+
+		return true
+	})
+
+	rb.State.RangeMembers(func(m *GuildMember) bool {
+		snapshot.Members = append(snapshot.Members, m)
+
+		return true
+	})
+
+	return snapshot
+}
+
+// SaveSnapshot writes a snapshot of the cached state to path. The file is
+// replaced atomically so a crash while writing does not lose the previous
+// snapshot.
+func (rb *RevoltBot) SaveSnapshot(path string) (err error) {
+	data, err := json.Marshal(rb.Snapshot())
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// LoadSnapshot adds the state in a snapshot written by SaveSnapshot to the
+// cache. The next Ready is compared against it and synthetic events are
+// dispatched for what changed while the bot was offline.
+func (rb *RevoltBot) LoadSnapshot(path string) (err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var snapshot *Snapshot
+
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return err
+	}
+
+	if snapshot.Version != SnapshotVersion {
+		return fmt.Errorf("%w %d", ErrSnapshotVersion, snapshot.Version)
+	}
+
+	rb.stateMu.Lock()
+	defer rb.stateMu.Unlock()
+
+	rb.State.Batch(func(c Cache) {
+		for _, u := range snapshot.Users {
+			c.SetUser(u)
+		}
+
+		for _, g := range snapshot.Guilds {
+			c.SetGuild(g)
+		}
+
+		for _, ch := range snapshot.Channels {
+			c.SetChannel(ch)
+		}
+
+		for _, m := range snapshot.Members {
+			if m.ID != nil {
+				c.SetMember(m)
+			}
+		}
+	})
+
+	rb.snapshot = snapshot
+
+	return nil
+}
+
+// reconcileSnapshot dispatches ServerDelete, ChannelDelete,
+// ServerMemberJoin and ServerMemberLeave events for the differences between
+// a loaded snapshot and the first Ready received after loading it.
+func (rb *RevoltBot) reconcileSnapshot(o Ready) {
+	rb.stateMu.Lock()
+	snapshot := rb.snapshot
+	rb.snapshot = nil
+	rb.stateMu.Unlock()
+
+	if snapshot == nil {
+		return
+	}
+
+	guilds := make(map[string]bool, len(o.Guilds))
+	for _, g := range o.Guilds {
+		guilds[g.ID] = true
+	}
+
+	channels := make(map[string]bool, len(o.Channels))
+	for _, c := range o.Channels {
+		channels[c.ID] = true
+	}
+
+	for _, g := range snapshot.Guilds {
+		if !guilds[g.ID] {
+			rb.handle(EventTypeServerDelete, ServerDelete{
+				SentBase: SentBase{EventTypeServerDelete},
+				GuildID:  g.ID,
+				Guild:    g,
+			})
+		}
+	}
+
+	for _, c := range snapshot.Channels {
+		if !channels[c.ID] {
+			rb.handle(EventTypeChannelDelete, ChannelDelete{
+				SentBase: SentBase{EventTypeChannelDelete},
+				ID:       c.ID,
+				Channel:  c,
+			})
+		}
+	}
+
+	// Ready does not include every member, so the members of the servers
+	// we knew the members of are fetched again and compared.
+	before := make(map[string]map[string]*GuildMember)
+	for _, m := range snapshot.Members {
+		if m.ID == nil || !guilds[m.ID.Server] {
+			continue
+		}
+
+		if before[m.ID.Server] == nil {
+			before[m.ID.Server] = make(map[string]*GuildMember)
+		}
+
+		before[m.ID.Server][m.ID.User] = m
+	}
+
+	for guildID, members := range before {
+		fetched, err := rb.FetchMembers(guildID)
+		if err != nil {
+			println("Failed to fetch members of " + guildID + ": " + err.Error())
+
+			continue
+		}
+
+		for _, m := range fetched.Members {
+			if _, ok := members[m.ID.User]; ok {
+				delete(members, m.ID.User)
+
+				continue
+			}
+
+			rb.handle(EventTypeServerMemberJoin, ServerMemberJoin{
+				SentBase: SentBase{EventTypeServerMemberJoin},
+				GuildID:  guildID,
+				UserID:   m.ID.User,
+				Member:   m,
+			})
+		}
+
+		for userID, m := range members {
+			rb.handle(EventTypeServerMemberLeave, ServerMemberLeave{
+				SentBase: SentBase{EventTypeServerMemberLeave},
+				GuildID:  guildID,
+				UserID:   userID,
+				Member:   m,
+			})
+		}
+	}
+}
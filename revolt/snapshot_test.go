@@ -0,0 +1,150 @@
+package revolt
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestSnapshotSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	saved := NewRevoltBot("")
+	applyFrames(t, saved, readyFixture)
+
+	if err := saved.SaveSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewRevoltBot("")
+	if err := loaded.LoadSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"bot", "user"} {
+		want, _ := saved.State.GetUser(id)
+		if got, ok := loaded.State.GetUser(id); !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("user %s = %+v, want %+v", id, got, want)
+		}
+
+		wantMember, _ := saved.State.GetMember("guild", id)
+		if got, ok := loaded.State.GetMember("guild", id); !ok || !reflect.DeepEqual(got, wantMember) {
+			t.Errorf("member %s = %+v, want %+v", id, got, wantMember)
+		}
+	}
+
+	want, _ := saved.State.GetGuild("guild")
+	if got, ok := loaded.State.GetGuild("guild"); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("guild = %+v, want %+v", got, want)
+	}
+
+	wantChannel, _ := saved.State.GetChannel("general")
+	if got, ok := loaded.State.GetChannel("general"); !ok || !reflect.DeepEqual(got, wantChannel) {
+		t.Errorf("channel = %+v, want %+v", got, wantChannel)
+	}
+}
+
+func TestLoadSnapshotVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	if err := ioutil.WriteFile(path, []byte(`{"version": 2}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := NewRevoltBot("").LoadSnapshot(path)
+	if !errors.Is(err, ErrSnapshotVersion) {
+		t.Errorf("LoadSnapshot() = %v, want ErrSnapshotVersion", err)
+	}
+}
+
+func TestReconcileSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	// The bot was in a server and channel that are gone from Ready, and
+	// knew of the members "user" and "left" in the server it is still in.
+	offline := NewRevoltBot("")
+	applyFrames(t, offline, readyFixture,
+		`{"type": "ChannelCreate", "_id": "gone", "channel_type": "TextChannel", "server": "guild", "name": "gone"}`,
+		`{"type": "ServerMemberJoin", "id": "guild", "user": "left"}`,
+	)
+	offline.State.SetGuild(&Guild{ID: "deleted"})
+
+	if err := offline.SaveSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+
+	rb := newTestBot(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/servers/guild/members" {
+			t.Errorf("request to %s", r.URL.Path)
+		}
+
+		w.Write([]byte(`{
+			"members": [
+				{"_id": {"server": "guild", "user": "bot"}},
+				{"_id": {"server": "guild", "user": "user"}},
+				{"_id": {"server": "guild", "user": "joined"}}
+			],
+			"users": []
+		}`))
+	}))
+
+	if err := rb.LoadSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+
+	var events []string
+
+	record := func(event string) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}
+
+	rb.AddHandler(func(rb *RevoltBot, o ServerDelete) {
+		record("ServerDelete " + o.GuildID)
+	})
+	rb.AddHandler(func(rb *RevoltBot, o ChannelDelete) {
+		record("ChannelDelete " + o.ID)
+	})
+	rb.AddHandler(func(rb *RevoltBot, o ServerMemberJoin) {
+		record("ServerMemberJoin " + o.UserID)
+	})
+	rb.AddHandler(func(rb *RevoltBot, o ServerMemberLeave) {
+		record("ServerMemberLeave " + o.UserID)
+	})
+
+	if err := rb.OnDispatch(EventTypeReady, []byte(readyFixture)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"ChannelDelete gone",
+		"ServerDelete deleted",
+		"ServerMemberJoin joined",
+		"ServerMemberLeave left",
+	}
+
+	sort.Strings(events)
+
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("dispatched %v, want %v", events, want)
+	}
+
+	// Only the first Ready after loading is reconciled.
+	events = nil
+
+	if err := rb.OnDispatch(EventTypeReady, []byte(readyFixture)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 0 {
+		t.Errorf("second Ready dispatched %v", events)
+	}
+}
@@ -0,0 +1,486 @@
+package revolt
+
+import "time"
+
+// updateState applies an event to the cached state before it is passed to
+// the registered handlers. Partial updates are decoded again from data on
+// top of a copy of the cached object.
+func (rb *RevoltBot) updateState(event interface{}, data []byte) interface{} {
+	// Handlers may change the state too, so updates are serialised to not
+	// lose changes made between reading and writing back an object.
+	rb.stateMu.Lock()
+	defer rb.stateMu.Unlock()
+
+	switch o := event.(type) {
+	case Pong:
+		rb.onPong(o)
+	case Ready:
+		rb.onReady(o)
+	case MessageCreate:
+		rb.onMessageCreate(o)
+	case MessageUpdate:
+		return rb.onMessageUpdate(o, data)
+	case MessageDelete:
+		return rb.onMessageDelete(o)
+	case ChannelCreate:
+		rb.onChannelCreate(o)
+	case ChannelUpdate:
+		return rb.onChannelUpdate(o, data)
+	case ChannelDelete:
+		return rb.onChannelDelete(o)
+	case ChannelGroupJoin:
+		rb.onChannelGroupJoin(o)
+	case ChannelGroupLeave:
+		rb.onChannelGroupLeave(o)
+	case ServerUpdate:
+		return rb.onServerUpdate(o, data)
+	case ServerDelete:
+		return rb.onServerDelete(o)
+	case ServerRoleUpdate:
+		return rb.onServerRoleUpdate(o, data)
+	case ServerRoleDelete:
+		return rb.onServerRoleDelete(o)
+	case ServerMemberJoin:
+		return rb.onServerMemberJoin(o)
+	case ServerMemberLeave:
+		return rb.onServerMemberLeave(o)
+	case ServerMemberUpdate:
+		return rb.onServerMemberUpdate(o, data)
+	case UserUpdate:
+		return rb.onUserUpdate(o, data)
+	}
+
+	return event
+}
+
+func (rb *RevoltBot) onReady(o Ready) {
+	rb.State.Batch(func(c Cache) {
+		// Ready is sent on every (re)connect, so whatever it no longer
+		// includes was removed while we were disconnected.
+		evictMissing(c, o)
+
+		for _, ch := range o.Channels {
+			c.SetChannel(ch)
+		}
+
+		for _, g := range o.Guilds {
+			c.SetGuild(g)
+		}
+
+		for _, u := range o.Users {
+			c.SetUser(u)
+
+			if u.Relationship == "User" {
+				rb.selfID = u.ID
+			}
+		}
+
+		for _, m := range o.Members {
+			if m.ID != nil {
+				c.SetMember(m)
+			}
+		}
+	})
+}
+
+// evictMissing removes the servers and channels that are not in a Ready
+// from the cache, along with the members of those servers. Ready only
+// includes the bot's own memberships, so the other members of servers that
+// are still present are kept. Users are kept as they may have been fetched
+// on demand and do not belong to a server that could have been left.
+func evictMissing(c Cache, o Ready) {
+	guilds := make(map[string]bool, len(o.Guilds))
+	for _, g := range o.Guilds {
+		guilds[g.ID] = true
+	}
+
+	channels := make(map[string]bool, len(o.Channels))
+	for _, ch := range o.Channels {
+		channels[ch.ID] = true
+	}
+
+	var ids []string
+
+	c.RangeChannels(func(ch *Channel) bool {
+		if !channels[ch.ID] {
+			ids = append(ids, ch.ID)
+		}
+
+		return true
+	})
+
+	for _, id := range ids {
+		c.DeleteChannel(id)
+	}
+
+	ids = ids[:0]
+
+	c.RangeGuilds(func(g *Guild) bool {
+		if !guilds[g.ID] {
+			ids = append(ids, g.ID)
+		}
+
+		return true
+	})
+
+	for _, id := range ids {
+		c.DeleteGuild(id)
+	}
+
+	var members []*GuildMemberIDs
+
+	c.RangeMembers(func(m *GuildMember) bool {
+		if !guilds[m.ID.Server] {
+			members = append(members, m.ID)
+		}
+
+		return true
+	})
+
+	for _, id := range members {
+		c.DeleteMember(id.Server, id.User)
+	}
+}
+
+func (rb *RevoltBot) onMessageCreate(o MessageCreate) {
+	rb.messages.add(o.Message)
+}
+
+func (rb *RevoltBot) onMessageUpdate(o MessageUpdate, data []byte) MessageUpdate {
+	before, ok := rb.messages.get(o.ID)
+	if !ok {
+		return o
+	}
+
+	o.Before = before
+	o.Message = before.copy()
+
+	err := rb.codec.Unmarshal(data, &o)
+	if err != nil {
+		println("Failed to merge MessageUpdate: " + err.Error())
+
+		return o
+	}
+
+	rb.messages.update(o.Message)
+
+	return o
+}
+
+func (rb *RevoltBot) onMessageDelete(o MessageDelete) MessageDelete {
+	o.Message, _ = rb.messages.remove(o.MessageID)
+
+	return o
+}
+
+func (rb *RevoltBot) onChannelCreate(o ChannelCreate) {
+	if o.Channel == nil {
+		return
+	}
+
+	rb.State.SetChannel(o.Channel)
+
+	if o.Channel.GuildID() == "" {
+		return
+	}
+
+	if guild, ok := rb.State.GetGuild(o.Channel.GuildID()); ok {
+		guild = guild.copy()
+		guild.Channels = addString(guild.Channels, o.Channel.ID)
+
+		rb.State.SetGuild(guild)
+	}
+}
+
+func (rb *RevoltBot) onChannelDelete(o ChannelDelete) ChannelDelete {
+	o.Channel, _ = rb.State.GetChannel(o.ID)
+	rb.State.DeleteChannel(o.ID)
+
+	rb.messages.removeChannel(o.ID)
+
+	if o.Channel == nil || o.Channel.GuildID() == "" {
+		return o
+	}
+
+	if guild, ok := rb.State.GetGuild(o.Channel.GuildID()); ok {
+		guild = guild.copy()
+		guild.Channels = removeString(guild.Channels, o.ID)
+
+		for i, category := range guild.Categories {
+			c := *category
+			c.Channels = removeString(category.Channels, o.ID)
+			guild.Categories[i] = &c
+		}
+
+		rb.State.SetGuild(guild)
+	}
+
+	return o
+}
+
+func (rb *RevoltBot) onChannelGroupJoin(o ChannelGroupJoin) {
+	if channel, ok := rb.State.GetChannel(o.ChannelID); ok && channel.Group != nil {
+		channel = channel.copy()
+		channel.Group.Recipients = addString(channel.Group.Recipients, o.UserID)
+
+		rb.State.SetChannel(channel)
+	}
+}
+
+func (rb *RevoltBot) onChannelGroupLeave(o ChannelGroupLeave) {
+	if channel, ok := rb.State.GetChannel(o.ChannelID); ok && channel.Group != nil {
+		channel = channel.copy()
+		channel.Group.Recipients = removeString(channel.Group.Recipients, o.UserID)
+
+		rb.State.SetChannel(channel)
+	}
+}
+
+func (rb *RevoltBot) onServerDelete(o ServerDelete) ServerDelete {
+	o.Guild, _ = rb.State.GetGuild(o.GuildID)
+
+	rb.State.Batch(func(c Cache) {
+		c.DeleteGuild(o.GuildID)
+
+		var channelIDs []string
+
+		c.RangeChannels(func(ch *Channel) bool {
+			if ch.GuildID() == o.GuildID {
+				channelIDs = append(channelIDs, ch.ID)
+			}
+
+			return true
+		})
+
+		for _, id := range channelIDs {
+			c.DeleteChannel(id)
+			rb.messages.removeChannel(id)
+		}
+
+		var userIDs []string
+
+		c.RangeMembers(func(m *GuildMember) bool {
+			if m.ID.Server == o.GuildID {
+				userIDs = append(userIDs, m.ID.User)
+			}
+
+			return true
+		})
+
+		for _, id := range userIDs {
+			c.DeleteMember(o.GuildID, id)
+		}
+	})
+
+	return o
+}
+
+func (rb *RevoltBot) onServerRoleDelete(o ServerRoleDelete) ServerRoleDelete {
+	if guild, ok := rb.State.GetGuild(o.GuildID); ok {
+		o.Role = guild.Roles[o.RoleID]
+
+		guild = guild.copy()
+		delete(guild.Roles, o.RoleID)
+
+		rb.State.SetGuild(guild)
+	}
+
+	for _, m := range rb.GuildMembers(o.GuildID) {
+		if roles := removeString(m.Roles, o.RoleID); len(roles) != len(m.Roles) {
+			m = m.copy()
+			m.Roles = roles
+
+			rb.State.SetMember(m)
+		}
+	}
+
+	return o
+}
+
+func (rb *RevoltBot) onChannelUpdate(o ChannelUpdate, data []byte) ChannelUpdate {
+	before, ok := rb.State.GetChannel(o.ID)
+	if !ok {
+		return o
+	}
+
+	o.Before = before
+	o.Channel = before.copy()
+
+	err := rb.codec.Unmarshal(data, &o)
+	if err != nil {
+		println("Failed to merge ChannelUpdate: " + err.Error())
+
+		return o
+	}
+
+	o.Channel.clear(o.Clear)
+
+	rb.State.SetChannel(o.Channel)
+
+	return o
+}
+
+func (rb *RevoltBot) onServerUpdate(o ServerUpdate, data []byte) ServerUpdate {
+	before, ok := rb.State.GetGuild(o.GuildID)
+	if !ok {
+		return o
+	}
+
+	o.Before = before
+	o.Guild = before.copy()
+
+	err := rb.codec.Unmarshal(data, &o)
+	if err != nil {
+		println("Failed to merge ServerUpdate: " + err.Error())
+
+		return o
+	}
+
+	o.Guild.clear(o.Clear)
+
+	rb.State.SetGuild(o.Guild)
+
+	return o
+}
+
+func (rb *RevoltBot) onServerRoleUpdate(o ServerRoleUpdate, data []byte) ServerRoleUpdate {
+	guild, ok := rb.State.GetGuild(o.GuildID)
+	if !ok {
+		return o
+	}
+
+	// Roles are created by updating a role that does not exist yet.
+	if before, ok := guild.Roles[o.RoleID]; ok {
+		o.Before = before
+		o.Role = before.copy()
+	} else {
+		o.Role = &GuildRole{}
+	}
+
+	err := rb.codec.Unmarshal(data, &o)
+	if err != nil {
+		println("Failed to merge ServerRoleUpdate: " + err.Error())
+
+		return o
+	}
+
+	o.Role.clear(o.Clear)
+
+	guild = guild.copy()
+	guild.Roles[o.RoleID] = o.Role
+
+	rb.State.SetGuild(guild)
+
+	return o
+}
+
+func (rb *RevoltBot) onServerMemberJoin(o ServerMemberJoin) ServerMemberJoin {
+	// The event does not say when the member joined, so the time it was
+	// received is used as an approximation.
+	o.Member = &GuildMember{
+		ID:       &GuildMemberIDs{Server: o.GuildID, User: o.UserID},
+		JoinedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	rb.State.SetMember(o.Member)
+
+	return o
+}
+
+func (rb *RevoltBot) onServerMemberLeave(o ServerMemberLeave) ServerMemberLeave {
+	o.Member, _ = rb.State.GetMember(o.GuildID, o.UserID)
+	rb.State.DeleteMember(o.GuildID, o.UserID)
+
+	return o
+}
+
+func (rb *RevoltBot) onServerMemberUpdate(o ServerMemberUpdate, data []byte) ServerMemberUpdate {
+	if o.ID == nil {
+		return o
+	}
+
+	before, ok := rb.State.GetMember(o.ID.Server, o.ID.User)
+	if !ok {
+		return o
+	}
+
+	o.Before = before
+	o.Member = before.copy()
+
+	err := rb.codec.Unmarshal(data, &o)
+	if err != nil {
+		println("Failed to merge ServerMemberUpdate: " + err.Error())
+
+		return o
+	}
+
+	o.Member.clear(o.Clear)
+
+	rb.State.SetMember(o.Member)
+
+	return o
+}
+
+func (rb *RevoltBot) onUserUpdate(o UserUpdate, data []byte) UserUpdate {
+	before, ok := rb.State.GetUser(o.UserID)
+	if !ok {
+		return o
+	}
+
+	o.Before = before
+	o.Data = before.copy()
+
+	err := rb.codec.Unmarshal(data, &o)
+	if err != nil {
+		println("Failed to merge UserUpdate: " + err.Error())
+
+		return o
+	}
+
+	o.Data.clear(o.Clear)
+
+	rb.State.SetUser(o.Data)
+
+	return o
+}
+
+// User returns the cached user with the specified ID, if any.
+func (rb *RevoltBot) User(userID string) (user *User, ok bool) {
+	return rb.State.GetUser(userID)
+}
+
+// SelfID returns the ID of the bot's own user once Ready has been received.
+func (rb *RevoltBot) SelfID() string {
+	rb.stateMu.Lock()
+	defer rb.stateMu.Unlock()
+
+	return rb.selfID
+}
+
+// Guild returns the cached server with the specified ID, if any.
+func (rb *RevoltBot) Guild(guildID string) (guild *Guild, ok bool) {
+	return rb.State.GetGuild(guildID)
+}
+
+// Member returns the cached member of a server, if any.
+func (rb *RevoltBot) Member(guildID string, userID string) (member *GuildMember, ok bool) {
+	return rb.State.GetMember(guildID, userID)
+}
+
+// GuildMembers returns the cached members of a server.
+func (rb *RevoltBot) GuildMembers(guildID string) (members []*GuildMember) {
+	rb.State.RangeMembers(func(m *GuildMember) bool {
+		if m.ID.Server == guildID {
+			members = append(members, m)
+		}
+
+		return true
+	})
+
+	return members
+}
+
+// Channel returns the cached channel with the specified ID, if any.
+func (rb *RevoltBot) Channel(channelID string) (channel *Channel, ok bool) {
+	return rb.State.GetChannel(channelID)
+}
@@ -0,0 +1,344 @@
+package revolt
+
+import (
+	"reflect"
+	"testing"
+)
+
+// applyFrames applies gateway frames, written as JSON and sent in the format
+// of rb.codec, to the state of rb in order, as the read loop does.
+func applyFrames(t *testing.T, rb *RevoltBot, frames ...string) {
+	t.Helper()
+
+	for _, frame := range frames {
+		data := []byte(frame)
+		if _, ok := rb.codec.(msgpackCodec); ok {
+			data = toMsgpack(t, frame)
+		}
+
+		mType, err := eventType(rb.codec, data)
+		if err != nil {
+			t.Fatalf("eventType(%s): %v", frame, err)
+		}
+
+		if _, err := rb.applyEvent(mType, data); err != nil {
+			t.Fatalf("applyEvent(%s): %v", frame, err)
+		}
+	}
+}
+
+const readyFixture = `{
+	"type": "Ready",
+	"users": [
+		{"_id": "bot", "username": "bot", "relationship": "User"},
+		{"_id": "user", "username": "someone", "avatar": {"_id": "avatar"}, "status": {"text": "hi", "presence": "Online"}}
+	],
+	"servers": [{
+		"_id": "guild", "owner": "user", "name": "server", "description": "about", "icon": {"_id": "icon"},
+		"channels": ["general"],
+		"roles": {"role": {"name": "role", "permissions": [1, 2], "colour": "red", "rank": 1}},
+		"default_permissions": [0, 0]
+	}],
+	"channels": [{"_id": "general", "channel_type": "TextChannel", "server": "guild", "name": "general", "description": "chat"}],
+	"members": [
+		{"_id": {"server": "guild", "user": "bot"}},
+		{"_id": {"server": "guild", "user": "user"}, "nickname": "nick", "avatar": {"_id": "avatar"}, "roles": ["role"]}
+	]
+}`
+
+func TestUpdateState(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []string
+		check  func(t *testing.T, rb *RevoltBot)
+	}{
+		{
+			name:   "partial ChannelUpdate",
+			frames: []string{`{"type": "ChannelUpdate", "id": "general", "data": {"name": "renamed"}}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				c, _ := rb.State.GetChannel("general")
+				if c.TextChannel == nil || c.TextChannel.Name != "renamed" || c.TextChannel.Server != "guild" || c.TextChannel.Description != "chat" {
+					t.Errorf("channel = %+v", c.TextChannel)
+				}
+			},
+		},
+		{
+			name:   "ChannelUpdate clear",
+			frames: []string{`{"type": "ChannelUpdate", "id": "general", "data": {}, "clear": "Description"}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				c, _ := rb.State.GetChannel("general")
+				if c.TextChannel.Description != "" || c.TextChannel.Name != "general" {
+					t.Errorf("channel = %+v", c.TextChannel)
+				}
+			},
+		},
+		{
+			name:   "partial ServerUpdate",
+			frames: []string{`{"type": "ServerUpdate", "id": "guild", "data": {"name": "renamed"}}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				g, _ := rb.State.GetGuild("guild")
+				if g.Name != "renamed" || g.Owner != "user" || !reflect.DeepEqual(g.Channels, []string{"general"}) || g.Roles["role"] == nil {
+					t.Errorf("guild = %+v", g)
+				}
+			},
+		},
+		{
+			name:   "ServerUpdate clear",
+			frames: []string{`{"type": "ServerUpdate", "id": "guild", "data": {}, "clear": "Icon"}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				g, _ := rb.State.GetGuild("guild")
+				if g.Icon != nil || g.Description != "about" {
+					t.Errorf("guild = %+v", g)
+				}
+			},
+		},
+		{
+			name: "ServerUpdates in order",
+			frames: []string{
+				`{"type": "ServerUpdate", "id": "guild", "data": {"name": "first"}}`,
+				`{"type": "ServerUpdate", "id": "guild", "data": {"name": "second"}}`,
+			},
+			check: func(t *testing.T, rb *RevoltBot) {
+				if g, _ := rb.State.GetGuild("guild"); g.Name != "second" {
+					t.Errorf("guild name = %q, want the last update", g.Name)
+				}
+			},
+		},
+		{
+			name:   "partial ServerRoleUpdate",
+			frames: []string{`{"type": "ServerRoleUpdate", "id": "guild", "role_id": "role", "data": {"name": "renamed"}, "clear": "Colour"}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				g, _ := rb.State.GetGuild("guild")
+				if r := g.Roles["role"]; r.Name != "renamed" || r.Rank != 1 || r.Colour != "" {
+					t.Errorf("role = %+v", r)
+				}
+			},
+		},
+		{
+			name:   "ServerRoleUpdate creates role",
+			frames: []string{`{"type": "ServerRoleUpdate", "id": "guild", "role_id": "new", "data": {"name": "new", "rank": 2}}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				g, _ := rb.State.GetGuild("guild")
+				if r := g.Roles["new"]; r == nil || r.Name != "new" || g.Roles["role"] == nil {
+					t.Errorf("roles = %+v", g.Roles)
+				}
+			},
+		},
+		{
+			name:   "ServerRoleDelete",
+			frames: []string{`{"type": "ServerRoleDelete", "id": "guild", "role_id": "role"}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				if g, _ := rb.State.GetGuild("guild"); g.Roles["role"] != nil {
+					t.Error("role still cached")
+				}
+
+				if m, _ := rb.State.GetMember("guild", "user"); len(m.Roles) != 0 {
+					t.Errorf("member roles = %v", m.Roles)
+				}
+			},
+		},
+		{
+			name:   "partial ServerMemberUpdate",
+			frames: []string{`{"type": "ServerMemberUpdate", "id": {"server": "guild", "user": "user"}, "data": {"nickname": "renamed"}, "clear": "Avatar"}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				m, _ := rb.State.GetMember("guild", "user")
+				if m.Nickname != "renamed" || m.Avatar != nil || !reflect.DeepEqual(m.Roles, []string{"role"}) {
+					t.Errorf("member = %+v", m)
+				}
+			},
+		},
+		{
+			name: "ServerMemberJoin and ServerMemberLeave",
+			frames: []string{
+				`{"type": "ServerMemberJoin", "id": "guild", "user": "new"}`,
+				`{"type": "ServerMemberLeave", "id": "guild", "user": "user"}`,
+			},
+			check: func(t *testing.T, rb *RevoltBot) {
+				if _, ok := rb.State.GetMember("guild", "new"); !ok {
+					t.Error("joined member not cached")
+				}
+
+				if _, ok := rb.State.GetMember("guild", "user"); ok {
+					t.Error("member that left still cached")
+				}
+			},
+		},
+		{
+			name:   "partial UserUpdate",
+			frames: []string{`{"type": "UserUpdate", "id": "user", "data": {"status": {"presence": "Busy"}}, "clear": "Avatar"}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				u, _ := rb.State.GetUser("user")
+				if u.Username != "someone" || u.Avatar != nil || u.Status == nil || u.Status.Presence != "Busy" {
+					t.Errorf("user = %+v", u)
+				}
+			},
+		},
+		{
+			name:   "ServerDelete",
+			frames: []string{`{"type": "ServerDelete", "id": "guild"}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				if _, ok := rb.State.GetGuild("guild"); ok {
+					t.Error("guild still cached")
+				}
+
+				if _, ok := rb.State.GetChannel("general"); ok {
+					t.Error("channel of the guild still cached")
+				}
+
+				if _, ok := rb.State.GetMember("guild", "user"); ok {
+					t.Error("member of the guild still cached")
+				}
+			},
+		},
+		{
+			name:   "update of uncached object",
+			frames: []string{`{"type": "ChannelUpdate", "id": "unknown", "data": {"name": "renamed"}}`},
+			check: func(t *testing.T, rb *RevoltBot) {
+				if _, ok := rb.State.GetChannel("unknown"); ok {
+					t.Error("partial channel cached")
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rb := NewRevoltBot("")
+
+			applyFrames(t, rb, readyFixture)
+			applyFrames(t, rb, test.frames...)
+
+			test.check(t, rb)
+		})
+	}
+}
+
+func TestChannelGroupRecipients(t *testing.T) {
+	const create = `{"type": "ChannelCreate", "_id": "group", "channel_type": "Group", "name": "friends", "owner": "user", "recipients": ["user", "bot"]}`
+
+	tests := []struct {
+		name   string
+		frames []string
+		want   []string
+	}{
+		{
+			name:   "join",
+			frames: []string{`{"type": "ChannelGroupJoin", "id": "group", "user": "new"}`},
+			want:   []string{"user", "bot", "new"},
+		},
+		{
+			name: "join twice",
+			frames: []string{
+				`{"type": "ChannelGroupJoin", "id": "group", "user": "new"}`,
+				`{"type": "ChannelGroupJoin", "id": "group", "user": "new"}`,
+			},
+			want: []string{"user", "bot", "new"},
+		},
+		{
+			name:   "leave",
+			frames: []string{`{"type": "ChannelGroupLeave", "id": "group", "user": "user"}`},
+			want:   []string{"bot"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rb := NewRevoltBot("")
+
+			applyFrames(t, rb, readyFixture, create)
+			applyFrames(t, rb, test.frames...)
+
+			c, _ := rb.State.GetChannel("group")
+			if !reflect.DeepEqual(c.Group.Recipients, test.want) {
+				t.Errorf("recipients = %v, want %v", c.Group.Recipients, test.want)
+			}
+		})
+	}
+}
+
+func TestChannelCreateDelete(t *testing.T) {
+	const create = `{"type": "ChannelCreate", "_id": "new", "channel_type": "TextChannel", "server": "guild", "name": "new"}`
+
+	tests := []struct {
+		name         string
+		frames       []string
+		wantChannels []string
+		wantCached   bool
+	}{
+		{
+			name:         "create",
+			frames:       []string{create},
+			wantChannels: []string{"general", "new"},
+			wantCached:   true,
+		},
+		{
+			name:         "duplicate create",
+			frames:       []string{create, create},
+			wantChannels: []string{"general", "new"},
+			wantCached:   true,
+		},
+		{
+			name:         "create then delete",
+			frames:       []string{create, `{"type": "ChannelDelete", "id": "new"}`},
+			wantChannels: []string{"general"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rb := NewRevoltBot("")
+
+			applyFrames(t, rb, readyFixture)
+			applyFrames(t, rb, test.frames...)
+
+			guild, _ := rb.State.GetGuild("guild")
+			if !reflect.DeepEqual(guild.Channels, test.wantChannels) {
+				t.Errorf("guild channels = %v, want %v", guild.Channels, test.wantChannels)
+			}
+
+			if _, ok := rb.State.GetChannel("new"); ok != test.wantCached {
+				t.Errorf("channel cached = %v, want %v", ok, test.wantCached)
+			}
+		})
+	}
+}
+
+func TestReadyReconnect(t *testing.T) {
+	rb := NewRevoltBot("")
+
+	applyFrames(t, rb, readyFixture,
+		// A member fetched with FetchMembers, which Ready does not include.
+		`{"type": "ServerMemberJoin", "id": "guild", "user": "fetched"}`,
+		`{"type": "ChannelCreate", "_id": "removed", "channel_type": "TextChannel", "server": "guild", "name": "removed"}`,
+	)
+
+	rb.State.SetGuild(&Guild{ID: "left", Channels: []string{"other"}})
+	rb.State.SetChannel(&Channel{ID: "other", ChannelType: ChannelTypeText, TextChannel: &TextChannel{Server: "left"}})
+	rb.State.SetMember(&GuildMember{ID: &GuildMemberIDs{Server: "left", User: "user"}})
+
+	applyFrames(t, rb, readyFixture)
+
+	if _, ok := rb.State.GetMember("guild", "fetched"); !ok {
+		t.Error("member of a server still in Ready evicted")
+	}
+
+	if _, ok := rb.State.GetChannel("removed"); ok {
+		t.Error("channel missing from Ready still cached")
+	}
+
+	if _, ok := rb.State.GetGuild("left"); ok {
+		t.Error("server missing from Ready still cached")
+	}
+
+	if _, ok := rb.State.GetChannel("other"); ok {
+		t.Error("channel of a server missing from Ready still cached")
+	}
+
+	if _, ok := rb.State.GetMember("left", "user"); ok {
+		t.Error("member of a server missing from Ready still cached")
+	}
+
+	if _, ok := rb.State.GetUser("user"); !ok {
+		t.Error("user evicted")
+	}
+}